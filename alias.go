@@ -0,0 +1,90 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Alias registers an alias of an event name, so dispatching the alias is
+// handled like dispatching the event, such as for a transport subject that
+// differs from the event name. The errors of the dispatch still report the
+// alias as the event name. Aliases are not resolved recursively, and a
+// handler registered later for the alias itself takes precedence.
+// It panics if the alias is already registered or has a handler.
+func (r *Rebound) Alias(alias, eventName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkAlias(alias, eventName); err != nil {
+		panic(err.Error())
+	}
+
+	r.addAlias(alias, eventName)
+}
+
+// checkAlias returns an error if the alias cannot be registered. It requires
+// r.mu to be held.
+func (r *Rebound) checkAlias(alias, eventName string) error {
+	if alias == "" || eventName == "" {
+		return ErrEmptyName
+	}
+
+	if _, exists := r.reg.aliases[alias]; exists {
+		return fmt.Errorf("rebound: alias %q is already registered", alias)
+	}
+
+	if r.hasHandler(alias) {
+		return fmt.Errorf("rebound: alias %q already has a handler", alias)
+	}
+
+	return nil
+}
+
+// addAlias registers the alias. It requires r.mu to be held.
+func (r *Rebound) addAlias(alias, eventName string) {
+	if r.reg.aliases == nil {
+		r.reg.aliases = make(map[string]string)
+	}
+
+	r.reg.aliases[alias] = eventName
+}
+
+// routesConfig is the format of the routes loaded by LoadRoutes.
+type routesConfig struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+// LoadRoutes registers the routes described by a JSON document, to keep the
+// routing configuration out of the code. The document maps each alias to its
+// event name (see Alias):
+//
+//	{
+//		"aliases": {
+//			"orders.v1.completed": "order.completed",
+//			"orders.v1.shipped": "order.shipped"
+//		}
+//	}
+//
+// The routes are registered all or none: an invalid document, or an alias
+// that cannot be registered, registers no route and returns an error.
+func (r *Rebound) LoadRoutes(data []byte) error {
+	var cfg routesConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("rebound: failed to parse routes: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for alias, eventName := range cfg.Aliases {
+		if err := r.checkAlias(alias, eventName); err != nil {
+			return err
+		}
+	}
+
+	for alias, eventName := range cfg.Aliases {
+		r.addAlias(alias, eventName)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestAlias(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	rb.Alias("orders.v1.completed", "order.completed")
+
+	if err := rb.Dispatch("orders.v1.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAlias_duplicate(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.Alias("orders.v1.completed", "order.completed")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb.Alias("orders.v1.completed", "order.shipped")
+}
+
+func TestLoadRoutes(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, "completed:"+event.OrderID)
+		return nil
+	})
+
+	rb.ReactTo("order.shipped", func(event OrderEvent) error {
+		got = append(got, "shipped:"+event.OrderID)
+		return nil
+	})
+
+	err := rb.LoadRoutes([]byte(`{
+		"aliases": {
+			"orders.v1.completed": "order.completed",
+			"orders.v1.shipped": "order.shipped"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("orders.v1.completed", []byte(`{"OrderID":"1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("orders.v1.shipped", []byte(`{"OrderID":"2"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("orders.v1.cancelled", []byte(`{}`)); !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+
+	if want := []string{"completed:1", "shipped:2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLoadRoutes_invalid(t *testing.T) {
+	testCases := map[string]string{
+		"malformed":      `{"aliases":`,
+		"empty alias":    `{"aliases": {"": "order.completed"}}`,
+		"empty name":     `{"aliases": {"orders.v1.completed": ""}}`,
+		"handled alias":  `{"aliases": {"order.completed": "order.shipped"}}`,
+		"existing alias": `{"aliases": {"orders.v1.shipped": "order.shipped"}}`,
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := &rebound.Rebound{}
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				return nil
+			})
+
+			rb.Alias("orders.v1.shipped", "order.shipped")
+
+			if err := rb.LoadRoutes([]byte(data)); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package rebound
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// AuditRecord describes a dispatch, for audit logging.
+type AuditRecord struct {
+	EventName string
+	Time      time.Time     // when the dispatch started
+	Duration  time.Duration // how long the dispatch took
+
+	// Err is the outcome of the dispatch, nil on success.
+	Err error
+
+	// PayloadHash is the hex-encoded SHA-256 hash of the event data, to
+	// correlate the records without storing the data. It is empty when the
+	// data is dispatched from a reader and not read into memory. With
+	// WithCanonicalHash, it is the hash of the canonical JSON data.
+	PayloadHash string
+}
+
+func (r *Rebound) audit(eventName string, start time.Time, data []byte, err error) {
+	rec := AuditRecord{
+		EventName: eventName,
+		Time:      start,
+		Duration:  r.clock().Now().Sub(start),
+		Err:       err,
+	}
+
+	if data != nil {
+		sum := sha256.Sum256(r.hashData(data))
+		rec.PayloadHash = hex.EncodeToString(sum[:])
+	}
+
+	r.auditFn(rec)
+}
@@ -0,0 +1,68 @@
+package rebound_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithAudit(t *testing.T) {
+	var recs []rebound.AuditRecord
+	rb := rebound.New(rebound.WithAudit(func(rec rebound.AuditRecord) {
+		recs = append(recs, rec)
+	}))
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		time.Sleep(time.Millisecond)
+		if event.OrderID == "" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	before := time.Now()
+
+	successData := []byte(`{"OrderID":"123"}`)
+	rb.Dispatch("order.completed", successData)
+
+	failureData := []byte(`{}`)
+	rb.Dispatch("order.completed", failureData)
+
+	if got, want := len(recs), 2; got != want {
+		t.Fatalf("got %d records, want %d", got, want)
+	}
+
+	for i, data := range [][]byte{successData, failureData} {
+		rec := recs[i]
+		if got, want := rec.EventName, "order.completed"; got != want {
+			t.Errorf("got event name %q, want %q", got, want)
+		}
+
+		if rec.Time.Before(before) {
+			t.Errorf("got time %v, want after %v", rec.Time, before)
+		}
+
+		if rec.Duration < time.Millisecond {
+			t.Errorf("got duration %v, want at least %v", rec.Duration, time.Millisecond)
+		}
+
+		sum := sha256.Sum256(data)
+		if got, want := rec.PayloadHash, hex.EncodeToString(sum[:]); got != want {
+			t.Errorf("got payload hash %q, want %q", got, want)
+		}
+	}
+
+	if err := recs[0].Err; err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+
+	if got, want := recs[1].Err, handlerErr; !errors.Is(got, want) {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,149 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Message is an event to dispatch, made of its name and data.
+type Message struct {
+	EventName string
+	Data      []byte
+}
+
+// DispatchBatch dispatches the messages in order, and returns their errors at
+// the corresponding index, nil for the messages dispatched successfully.
+//
+// Once ctx is done, the remaining messages are not dispatched and get
+// ctx.Err() as their error.
+func (r *Rebound) DispatchBatch(ctx context.Context, msgs []Message) []error {
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(msgs); j++ {
+				errs[j] = err
+			}
+
+			break
+		}
+
+		errs[i] = r.DispatchContext(ctx, msg.EventName, msg.Data)
+	}
+
+	return errs
+}
+
+// DispatchBatchConcurrent is like DispatchBatch, but dispatches the messages
+// concurrently using the given number of workers, at least one. The errors
+// are still returned at the index of their message, but the messages are not
+// dispatched in order, so the handlers must be safe for concurrent use. The
+// messages are dispatched like by DispatchAsync, so RecoverAsync recovers
+// from the panics of their handlers.
+func (r *Rebound) DispatchBatchConcurrent(ctx context.Context, msgs []Message, workers int) []error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > len(msgs) {
+		workers = len(msgs)
+	}
+
+	errs := make([]error, len(msgs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				// the workers are goroutines of their own, so the messages are
+				// dispatched like by DispatchAsync (see RecoverAsync)
+				_, errs[i] = r.dispatch(ctx, msgs[i].EventName, payload{data: msgs[i].Data, async: true})
+			}
+		}()
+	}
+
+	for i := range msgs {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
+
+// DispatchBatchDeadline is like DispatchBatch, but bounds the whole batch to
+// the duration d, as measured by the Rebound clock (see WithClock). The
+// messages remaining once the deadline passes get context.DeadlineExceeded as
+// their error.
+func (r *Rebound) DispatchBatchDeadline(ctx context.Context, msgs []Message, d time.Duration) []error {
+	ctx, cancel := r.withTimeout(ctx, d)
+	defer cancel()
+
+	return r.DispatchBatch(ctx, msgs)
+}
+
+// BatchError indicates that some messages of a batch failed to dispatch.
+type BatchError struct {
+	total  int
+	failed map[int]error
+}
+
+// Error returns the error message for BatchError.
+func (e BatchError) Error() string {
+	return fmt.Sprintf("rebound: %d of %d messages failed", len(e.failed), e.total)
+}
+
+// Failed returns the errors of the failed messages, by message index.
+func (e BatchError) Failed() map[int]error {
+	failed := make(map[int]error, len(e.failed))
+	for i, err := range e.failed {
+		failed[i] = err
+	}
+
+	return failed
+}
+
+// Unwrap returns the errors of the failed messages, in message order.
+func (e BatchError) Unwrap() []error {
+	indexes := make([]int, 0, len(e.failed))
+	for i := range e.failed {
+		indexes = append(indexes, i)
+	}
+
+	sort.Ints(indexes)
+
+	errs := make([]error, len(indexes))
+	for j, i := range indexes {
+		errs[j] = e.failed[i]
+	}
+
+	return errs
+}
+
+// DispatchBatchErr is like DispatchBatch, but returns a BatchError holding
+// the errors of the failed messages, or nil if they all succeed.
+func (r *Rebound) DispatchBatchErr(ctx context.Context, msgs []Message) error {
+	failed := make(map[int]error)
+	for i, err := range r.DispatchBatch(ctx, msgs) {
+		if err != nil {
+			failed[i] = err
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return BatchError{total: len(msgs), failed: failed}
+}
@@ -0,0 +1,251 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDispatchBatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	handlerErr := errors.New("handler error")
+
+	var orderIDs []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		orderIDs = append(orderIDs, event.OrderID)
+		if event.OrderID == "2" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	msgs := []rebound.Message{
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"1"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"2"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"3"}`)},
+	}
+
+	errs := rb.DispatchBatch(context.Background(), msgs)
+	want := []error{nil, handlerErr, nil}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(want))
+	}
+
+	for i := range want {
+		if !errors.Is(errs[i], want[i]) {
+			t.Errorf("message %d: got error %v, want %v", i, errs[i], want[i])
+		}
+	}
+
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(orderIDs, want) {
+		t.Errorf("got %v, want %v", orderIDs, want)
+	}
+}
+
+func TestDispatchBatchDeadline(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var orderIDs []string
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		orderIDs = append(orderIDs, event.OrderID)
+		if event.OrderID == "slow" {
+			<-ctx.Done()
+		}
+
+		return nil
+	})
+
+	var msgs []rebound.Message
+	for i := 0; i < 100; i++ {
+		orderID := fmt.Sprint(i)
+		if i == 40 {
+			orderID = "slow"
+		}
+
+		msgs = append(msgs, rebound.Message{
+			EventName: "order.completed",
+			Data:      []byte(fmt.Sprintf(`{"OrderID":%q}`, orderID)),
+		})
+	}
+
+	errs := rb.DispatchBatchDeadline(context.Background(), msgs, 10*time.Millisecond)
+
+	if got, want := len(errs), len(msgs); got != want {
+		t.Fatalf("got %d errors, want %d", got, want)
+	}
+
+	for i, err := range errs {
+		switch {
+		case i <= 40 && err != nil:
+			t.Errorf("message %d: got error %v, want nil", i, err)
+		case i > 40 && !errors.Is(err, context.DeadlineExceeded):
+			t.Errorf("message %d: got error %v, want %v", i, err, context.DeadlineExceeded)
+		}
+	}
+
+	if got, want := len(orderIDs), 41; got != want {
+		t.Errorf("got %d handled, want %d", got, want)
+	}
+}
+
+func TestDispatchBatchErr(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "fail" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	t.Run("all success", func(t *testing.T) {
+		msgs := []rebound.Message{
+			{EventName: "order.completed", Data: []byte(`{"OrderID":"1"}`)},
+			{EventName: "order.completed", Data: []byte(`{"OrderID":"2"}`)},
+		}
+
+		if err := rb.DispatchBatchErr(context.Background(), msgs); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		msgs := []rebound.Message{
+			{EventName: "order.completed", Data: []byte(`{"OrderID":"1"}`)},
+			{EventName: "order.completed", Data: []byte(`{"OrderID":"fail"}`)},
+			{EventName: "order.canceled", Data: []byte(`{"OrderID":"3"}`)},
+		}
+
+		err := rb.DispatchBatchErr(context.Background(), msgs)
+
+		var batchErr rebound.BatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("got error %v, want %T", err, batchErr)
+		}
+
+		if got, want := err.Error(), "rebound: 2 of 3 messages failed"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		failed := batchErr.Failed()
+		if got, want := len(failed), 2; got != want {
+			t.Fatalf("got %d failed, want %d", got, want)
+		}
+
+		if !errors.Is(failed[1], handlerErr) {
+			t.Errorf("got error %v, want %v", failed[1], handlerErr)
+		}
+
+		if !errors.Is(failed[2], rebound.ErrNoHandler) {
+			t.Errorf("got error %v, want %v", failed[2], rebound.ErrNoHandler)
+		}
+
+		if !errors.Is(err, handlerErr) || !errors.Is(err, rebound.ErrNoHandler) {
+			t.Errorf("got error %v, want it to wrap the failures", err)
+		}
+	})
+}
+
+func TestDispatchBatchConcurrent(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	const workers = 4
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxIn    int
+	)
+
+	started := make(chan struct{}, workers)
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		// hold the first handlers until all the workers are busy
+		select {
+		case started <- struct{}{}:
+			for len(started) < workers {
+				time.Sleep(time.Millisecond)
+			}
+		default:
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if event.OrderID == "err" {
+			return fmt.Errorf("order %s failed", event.OrderID)
+		}
+
+		return nil
+	})
+
+	var msgs []rebound.Message
+	for i := 0; i < 20; i++ {
+		orderID := strconv.Itoa(i)
+		if i%3 == 0 {
+			orderID = "err"
+		}
+
+		msgs = append(msgs, rebound.Message{
+			EventName: "order.completed",
+			Data:      []byte(`{"OrderID":"` + orderID + `"}`),
+		})
+	}
+
+	errs := rb.DispatchBatchConcurrent(context.Background(), msgs, workers)
+	if len(errs) != len(msgs) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(msgs))
+	}
+
+	for i, err := range errs {
+		if wantErr := i%3 == 0; (err != nil) != wantErr {
+			t.Errorf("message %d: got error %v, want error %t", i, err, wantErr)
+		}
+	}
+
+	if maxIn != workers {
+		t.Errorf("got %d handlers in flight, want %d", maxIn, workers)
+	}
+}
+
+func TestDispatchBatchConcurrent_recoverAsync(t *testing.T) {
+	rb := rebound.New(rebound.WithRecover(rebound.RecoverAsync))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "panic" {
+			panic("boom")
+		}
+
+		return nil
+	})
+
+	msgs := []rebound.Message{
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"1"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"panic"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"2"}`)},
+	}
+
+	errs := rb.DispatchBatchConcurrent(context.Background(), msgs, 2)
+
+	var panicErr rebound.PanicError
+	if errs[0] != nil || !errors.As(errs[1], &panicErr) || errs[2] != nil {
+		t.Errorf("got errors %v, want a %T for the second message only", errs, panicErr)
+	}
+}
@@ -0,0 +1,46 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Bus is an in-process event bus, publishing events to the handlers of a
+// Rebound. The events are encoded to JSON, so the Rebound should use the
+// JSONDecoder.
+type Bus struct {
+	r *Rebound
+}
+
+// NewBus creates a new Bus publishing events to r. When r is nil, the bus
+// uses a new Rebound.
+func NewBus(r *Rebound) *Bus {
+	if r == nil {
+		r = &Rebound{}
+	}
+
+	return &Bus{r: r}
+}
+
+// Rebound returns the Rebound the bus publishes events to.
+func (b *Bus) Rebound() *Rebound {
+	return b.r
+}
+
+// Publish encodes the event and dispatches it to the subscribers of the
+// event name.
+func (b *Bus) Publish(eventName string, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("rebound: failed to encode event %q: %w", eventName, err)
+	}
+
+	return b.r.Dispatch(eventName, data)
+}
+
+// Subscribe registers an event handler for a given event name. An event may
+// have multiple subscribers, all of them handling the same event type, called
+// in subscription order (see ReactToWithPriority).
+func (b *Bus) Subscribe(eventName string, fn EventHandler) {
+	b.r.ReactToWithPriority(eventName, 0, fn)
+}
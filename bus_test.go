@@ -0,0 +1,47 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestBus(t *testing.T) {
+	bus := rebound.NewBus(nil)
+
+	var got []string
+	bus.Subscribe("order.completed", func(event OrderEvent) error {
+		got = append(got, "first "+event.OrderID)
+		return nil
+	})
+
+	bus.Subscribe("order.completed", func(event OrderEvent) error {
+		got = append(got, "second "+event.OrderID)
+		return nil
+	})
+
+	if err := bus.Publish("order.completed", OrderEvent{OrderID: "123"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"first 123", "second 123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestBus_errors(t *testing.T) {
+	bus := rebound.NewBus(rebound.New())
+	bus.Subscribe("order.completed", func(event OrderEvent) error {
+		return nil
+	})
+
+	if err := bus.Publish("order.canceled", OrderEvent{OrderID: "123"}); !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+
+	if err := bus.Publish("order.completed", make(chan int)); err == nil {
+		t.Error("expect error")
+	}
+}
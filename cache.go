@@ -0,0 +1,96 @@
+package rebound
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"reflect"
+	"sync"
+)
+
+type decodeCacheKey struct {
+	eventType reflect.Type
+	sum       [sha256.Size]byte
+}
+
+func newDecodeCacheKey(eventName string, eventType reflect.Type, data []byte) decodeCacheKey {
+	h := sha256.New()
+	h.Write([]byte(eventName))
+	h.Write([]byte{0})
+	h.Write(data)
+
+	key := decodeCacheKey{eventType: eventType}
+	h.Sum(key.sum[:0])
+	return key
+}
+
+type decodeCacheEntry struct {
+	key   decodeCacheKey
+	event reflect.Value
+}
+
+// decodeCache is a least recently used cache of decoded events.
+type decodeCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[decodeCacheKey]*list.Element
+}
+
+func newDecodeCache(size int) *decodeCache {
+	return &decodeCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[decodeCacheKey]*list.Element),
+	}
+}
+
+// get returns a deep copy of the cached event, so the handlers mutating it
+// do not alter the cache.
+func (c *decodeCache) get(key decodeCacheKey) (reflect.Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return deepCopy(elem.Value.(*decodeCacheEntry).event), true
+}
+
+// add caches a deep copy of the event.
+func (c *decodeCache) add(key decodeCacheKey, event reflect.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*decodeCacheEntry).event = deepCopy(event)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&decodeCacheEntry{key: key, event: deepCopy(event)})
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*decodeCacheEntry).key)
+	}
+}
+
+func (c *decodeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	clear(c.items)
+}
+
+// ClearCaches empties the decoded events cache (see WithDecodeCache), keeping
+// the registered handlers. It is useful to avoid carry-over effects between
+// benchmark runs.
+func (r *Rebound) ClearCaches() {
+	if r.decodeCache != nil {
+		r.decodeCache.clear()
+	}
+}
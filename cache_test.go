@@ -0,0 +1,175 @@
+package rebound_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+// countingDecoder is a JSON decoder counting the decodes.
+type countingDecoder struct {
+	count int
+}
+
+func (d *countingDecoder) Decode(data []byte, v interface{}) error {
+	d.count++
+	return json.Unmarshal(data, v)
+}
+
+func TestWithDecodeCache(t *testing.T) {
+	dec := &countingDecoder{}
+	rb := rebound.New(rebound.WithDecodeCache(10))
+	rb.Decoder = dec
+
+	var orderIDs []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		orderIDs = append(orderIDs, event.OrderID)
+		return nil
+	})
+
+	for _, data := range []string{`{"OrderID":"1"}`, `{"OrderID":"1"}`, `{"OrderID":"2"}`, `{"OrderID":"1"}`} {
+		if err := rb.Dispatch("order.completed", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := dec.count, 2; got != want {
+		t.Errorf("got %d decodes, want %d", got, want)
+	}
+
+	if got, want := len(orderIDs), 4; got != want {
+		t.Fatalf("got %d calls, want %d", got, want)
+	}
+
+	if got, want := orderIDs[3], "1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWithDecodeCache_eviction(t *testing.T) {
+	dec := &countingDecoder{}
+	rb := rebound.New(rebound.WithDecodeCache(1))
+	rb.Decoder = dec
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	for _, data := range []string{`{"OrderID":"1"}`, `{"OrderID":"2"}`, `{"OrderID":"1"}`} {
+		if err := rb.Dispatch("order.completed", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := dec.count, 3; got != want {
+		t.Errorf("got %d decodes, want %d", got, want)
+	}
+}
+
+func TestWithDecodeCache_noAliasing(t *testing.T) {
+	rb := rebound.New(rebound.WithDecodeCache(10))
+
+	type OrderCompleted struct {
+		OrderID string
+		Items   [2]string
+	}
+
+	var got []OrderCompleted
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		got = append(got, event)
+		event.OrderID = "mutated"
+		event.Items[0] = "mutated"
+		return nil
+	})
+
+	data := []byte(`{"OrderID":"1","Items":["a","b"]}`)
+	for i := 0; i < 2; i++ {
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := OrderCompleted{OrderID: "1", Items: [2]string{"a", "b"}}
+	if got[1] != want {
+		t.Errorf("got %+v, want %+v", got[1], want)
+	}
+}
+
+func TestClearCaches(t *testing.T) {
+	dec := &countingDecoder{}
+	rb := rebound.New(rebound.WithDecodeCache(10))
+	rb.Decoder = dec
+
+	var calls int
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		calls++
+		return nil
+	})
+
+	data := []byte(`{"OrderID":"1"}`)
+	dispatch := func() {
+		t.Helper()
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	dispatch()
+	dispatch()
+	if got, want := dec.count, 1; got != want {
+		t.Fatalf("got %d decodes before clearing, want %d", got, want)
+	}
+
+	rb.ClearCaches()
+
+	dispatch()
+	if got, want := dec.count, 2; got != want {
+		t.Errorf("got %d decodes after clearing, want %d", got, want)
+	}
+
+	dispatch()
+	if got, want := dec.count, 2; got != want {
+		t.Errorf("got %d decodes after repopulating, want %d", got, want)
+	}
+
+	if got, want := calls, 4; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestClearCaches_noCache(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ClearCaches()
+}
+
+func TestWithDecodeCache_mutation(t *testing.T) {
+	type CartEvent struct {
+		Items []string
+		Meta  map[string]string
+		Note  *string
+	}
+
+	rb := rebound.New(rebound.WithDecodeCache(10))
+
+	var calls int
+	rb.ReactTo("cart.updated", func(event CartEvent) error {
+		calls++
+		if event.Items[0] != "a" || event.Meta["k"] != "v" || *event.Note != "n" {
+			t.Errorf("call %d: got %v, %v and %q, want the event unaltered", calls, event.Items, event.Meta, *event.Note)
+		}
+
+		event.Items[0] = "MUTATED"
+		event.Meta["k"] = "MUTATED"
+		*event.Note = "MUTATED"
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := rb.Dispatch("cart.updated", []byte(`{"Items":["a"],"Meta":{"k":"v"},"Note":"n"}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := calls, 3; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package rebound
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// CanonicalJSON returns the canonical form of the JSON data, compacted with
+// the object keys sorted, so logically equal documents differing only in key
+// order or whitespace get identical bytes. Numbers are kept as written, so 1
+// and 1.0 still differ.
+func CanonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("rebound: invalid data after top-level value")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// hashData returns the data to hash for the decode cache and the audit
+// records, canonicalized if enabled (see WithCanonicalHash). Data that is not
+// valid JSON is hashed as is.
+func (r *Rebound) hashData(data []byte) []byte {
+	if !r.canonicalHash {
+		return data
+	}
+
+	canonical, err := CanonicalJSON(data)
+	if err != nil {
+		return data
+	}
+
+	return canonical
+}
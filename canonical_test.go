@@ -0,0 +1,96 @@
+package rebound_test
+
+import (
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	testCases := map[string]struct {
+		a, b string
+		want string
+	}{
+		"key order": {
+			a:    `{"OrderID":"123","Amount":10}`,
+			b:    `{"Amount":10,"OrderID":"123"}`,
+			want: `{"Amount":10,"OrderID":"123"}`,
+		},
+		"whitespace": {
+			a:    "{\n  \"OrderID\": \"123\",\n  \"Tags\": [ \"a\", \"b\" ]\n}",
+			b:    `{"OrderID":"123","Tags":["a","b"]}`,
+			want: `{"OrderID":"123","Tags":["a","b"]}`,
+		},
+		"nested": {
+			a:    `{"b":{"y":1,"x":2},"a":[{"d":true,"c":null}]}`,
+			b:    ` {"a":[{"c":null,"d":true}],"b":{"x":2,"y":1}} `,
+			want: `{"a":[{"c":null,"d":true}],"b":{"x":2,"y":1}}`,
+		},
+		"large number": {
+			a:    `{"ID":12345678901234567890}`,
+			b:    `{ "ID": 12345678901234567890 }`,
+			want: `{"ID":12345678901234567890}`,
+		},
+		"html": {
+			a:    `{"Note":"<a&b>"}`,
+			b:    `{ "Note" : "<a&b>" }`,
+			want: `{"Note":"<a&b>"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, err := rebound.CanonicalJSON([]byte(tc.a))
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			b, err := rebound.CanonicalJSON([]byte(tc.b))
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if string(a) != tc.want || string(b) != tc.want {
+				t.Errorf("got %s and %s, want %s", a, b, tc.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSON_invalid(t *testing.T) {
+	for _, data := range []string{``, `{"OrderID":`, `{} {}`} {
+		if _, err := rebound.CanonicalJSON([]byte(data)); err == nil {
+			t.Errorf("got nil error for %q, want error", data)
+		}
+	}
+}
+
+func TestWithCanonicalHash(t *testing.T) {
+	testCases := map[string]struct {
+		enabled  bool
+		wantSame bool
+	}{
+		"default": {},
+		"enabled": {enabled: true, wantSame: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var hashes []string
+			rb := rebound.New(rebound.WithCanonicalHash(tc.enabled), rebound.WithAudit(func(rec rebound.AuditRecord) {
+				hashes = append(hashes, rec.PayloadHash)
+			}))
+
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				return nil
+			})
+
+			rb.Dispatch("order.completed", []byte(`{"OrderID":"123","Extra":1}`))
+			rb.Dispatch("order.completed", []byte(`{ "Extra": 1, "OrderID": "123" }`))
+
+			if got := hashes[0] == hashes[1]; got != tc.wantSame {
+				t.Errorf("got hashes %q, want same %t", hashes, tc.wantSame)
+			}
+		})
+	}
+}
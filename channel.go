@@ -0,0 +1,157 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChannelFullError is the error returned when a channel registered using
+// ReactToChannel has no room for the dispatched event.
+type ChannelFullError struct {
+	EventName string
+}
+
+// Error returns the error message for ChannelFullError.
+func (e ChannelFullError) Error() string {
+	return fmt.Sprintf("rebound: channel of event %q is full", e.EventName)
+}
+
+// OverflowPolicy defines what happens to an event sent to a full channel
+// registered using ReactToChannel.
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest drops the dispatched event, and the dispatch returns
+	// a ChannelFullError. This is the default.
+	OverflowDropNewest OverflowPolicy = iota
+
+	// OverflowDropOldest drops the oldest event queued in the channel to make
+	// room for the dispatched event. If there is still no room, such as for an
+	// unbuffered channel without a receiver ready, the dispatched event is
+	// dropped like OverflowDropNewest.
+	OverflowDropOldest
+
+	// OverflowBlock blocks the dispatch until the channel has room, or the
+	// dispatch context is done.
+	OverflowBlock
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowBlock:
+		return "block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// ReactToChannel registers a channel receiving the events with the given
+// name. The events are decoded like for a handler taking an interface{}
+// event, into the concrete types registered using RegisterType, or as is,
+// such as into a map[string]interface{}, if no type is registered.
+//
+// A full channel is handled according to the overflow policy (see
+// WithOverflowPolicy). The events dropped are counted by DroppedEvents.
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToChannel(eventName string, ch chan interface{}) {
+	if ch == nil {
+		panic("rebound: ch is nil")
+	}
+
+	r.ReactTo(eventName, func(ctx context.Context, event interface{}) error {
+		return r.send(ctx, nil, eventName, ch, event)
+	})
+}
+
+// ReactToChannelCtx is like ReactToChannel, but the channel is unregistered
+// once ctx is done, and no event is sent to it anymore.
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToChannelCtx(ctx context.Context, eventName string, ch chan interface{}) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	if ch == nil {
+		panic("rebound: ch is nil")
+	}
+
+	h := newHandler(func(dispatchCtx context.Context, event interface{}) error {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return r.send(dispatchCtx, ctx.Done(), eventName, ch, event)
+	}, 0)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	r.addHandler(eventName, h)
+
+	context.AfterFunc(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.removeHandler(eventName, h)
+	})
+}
+
+// send sends the event to the channel according to the overflow policy. When
+// blocking, it gives up once ctx or done is done.
+func (r *Rebound) send(ctx context.Context, done <-chan struct{}, eventName string, ch chan interface{}, event interface{}) error {
+	switch r.overflow {
+	case OverflowBlock:
+		select {
+		case ch <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return nil
+		}
+	case OverflowDropOldest:
+		select {
+		case ch <- event:
+			return nil
+		default:
+		}
+
+		select {
+		case <-ch:
+			r.dropped.Add(1)
+		default:
+		}
+
+		// the room made may already be taken, or the channel unbuffered, so
+		// the event is dropped rather than retrying forever
+		select {
+		case ch <- event:
+			return nil
+		default:
+			r.dropped.Add(1)
+			return ChannelFullError{EventName: eventName}
+		}
+	default:
+		select {
+		case ch <- event:
+			return nil
+		default:
+			r.dropped.Add(1)
+			return ChannelFullError{EventName: eventName}
+		}
+	}
+}
+
+// DroppedEvents returns the number of events dropped because of a full
+// channel registered using ReactToChannel.
+func (r *Rebound) DroppedEvents() uint64 {
+	return r.dropped.Load()
+}
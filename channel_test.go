@@ -0,0 +1,264 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+type InvoiceIssued struct {
+	InvoiceID string
+}
+
+func TestReactToChannel(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ch := make(chan interface{}, 2)
+	rb.ReactToChannel("invoice.issued", ch)
+
+	for _, data := range []string{
+		`{"type":"invoice.issued","InvoiceID":"inv-1"}`,
+		`{"type":"invoice.issued","InvoiceID":"inv-2"}`,
+	} {
+		if err := rb.Dispatch("invoice.issued", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, want := range []string{"inv-1", "inv-2"} {
+		event, ok := (<-ch).(InvoiceIssued)
+		if !ok {
+			t.Fatalf("got %T, want %T", event, InvoiceIssued{})
+		}
+
+		if got := event.InvoiceID; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestReactToChannel_untyped(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	ch := make(chan interface{}, 1)
+	rb.ReactToChannel("invoice.issued", ch)
+
+	if err := rb.Dispatch("invoice.issued", []byte(`{"InvoiceID":"inv-1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	event, ok := (<-ch).(map[string]interface{})
+	if !ok {
+		t.Fatalf("got %T, want %T", event, map[string]interface{}{})
+	}
+
+	if got, want := event["InvoiceID"], "inv-1"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReactToChannel_full(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ch := make(chan interface{}, 1)
+	rb.ReactToChannel("invoice.issued", ch)
+
+	data := []byte(`{"type":"invoice.issued","InvoiceID":"inv-1"}`)
+	if err := rb.Dispatch("invoice.issued", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := rb.Dispatch("invoice.issued", data)
+
+	var fullErr rebound.ChannelFullError
+	if !errors.As(err, &fullErr) {
+		t.Fatalf("got error %v, want %T", err, fullErr)
+	}
+
+	if got, want := fullErr.EventName, "invoice.issued"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, want := len(ch), 1; got != want {
+		t.Errorf("got %d queued events, want %d", got, want)
+	}
+
+	if got, want := rb.DroppedEvents(), uint64(1); got != want {
+		t.Errorf("got %d dropped events, want %d", got, want)
+	}
+}
+
+func TestReactToChannel_dropOldest(t *testing.T) {
+	rb := rebound.New(rebound.WithOverflowPolicy(rebound.OverflowDropOldest))
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ch := make(chan interface{}, 2)
+	rb.ReactToChannel("invoice.issued", ch)
+
+	for _, data := range []string{
+		`{"type":"invoice.issued","InvoiceID":"inv-1"}`,
+		`{"type":"invoice.issued","InvoiceID":"inv-2"}`,
+		`{"type":"invoice.issued","InvoiceID":"inv-3"}`,
+	} {
+		if err := rb.Dispatch("invoice.issued", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := rb.DroppedEvents(), uint64(1); got != want {
+		t.Errorf("got %d dropped events, want %d", got, want)
+	}
+
+	for _, want := range []string{"inv-2", "inv-3"} {
+		if got := (<-ch).(InvoiceIssued).InvoiceID; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestReactToChannel_dropOldestUnbuffered(t *testing.T) {
+	rb := rebound.New(rebound.WithOverflowPolicy(rebound.OverflowDropOldest))
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ch := make(chan interface{})
+	rb.ReactToChannel("invoice.issued", ch)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- rb.Dispatch("invoice.issued", []byte(`{"type":"invoice.issued","InvoiceID":"inv-1"}`))
+	}()
+
+	select {
+	case err := <-done:
+		var fullErr rebound.ChannelFullError
+		if !errors.As(err, &fullErr) {
+			t.Errorf("got error %v, want %T", err, fullErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dispatch did not return")
+	}
+
+	if got, want := rb.DroppedEvents(), uint64(1); got != want {
+		t.Errorf("got %d dropped events, want %d", got, want)
+	}
+}
+
+func TestReactToChannel_block(t *testing.T) {
+	rb := rebound.New(rebound.WithOverflowPolicy(rebound.OverflowBlock))
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ch := make(chan interface{}, 1)
+	rb.ReactToChannel("invoice.issued", ch)
+
+	data := []byte(`{"type":"invoice.issued","InvoiceID":"inv-1"}`)
+	if err := rb.Dispatch("invoice.issued", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := rb.DispatchContext(ctx, "invoice.issued", data); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- rb.Dispatch("invoice.issued", data)
+	}()
+
+	<-ch
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := len(ch), 1; got != want {
+		t.Errorf("got %d queued events, want %d", got, want)
+	}
+
+	if got, want := rb.DroppedEvents(), uint64(0); got != want {
+		t.Errorf("got %d dropped events, want %d", got, want)
+	}
+}
+
+func TestOverflowPolicy_String(t *testing.T) {
+	testCases := map[rebound.OverflowPolicy]string{
+		rebound.OverflowDropNewest: "drop-newest",
+		rebound.OverflowDropOldest: "drop-oldest",
+		rebound.OverflowBlock:      "block",
+		rebound.OverflowPolicy(42): "OverflowPolicy(42)",
+	}
+
+	for policy, want := range testCases {
+		if got := policy.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestReactToChannelCtx(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan interface{}, 2)
+	rb.ReactToChannelCtx(ctx, "invoice.issued", ch)
+
+	data := []byte(`{"type":"invoice.issued","InvoiceID":"inv-1"}`)
+	if err := rb.Dispatch("invoice.issued", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := (<-ch).(InvoiceIssued).InvoiceID, "inv-1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	cancel()
+
+	rb.Dispatch("invoice.issued", data)
+	if got := len(ch); got != 0 {
+		t.Errorf("got %d queued events, want 0", got)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for rb.Explain("invoice.issued").Kind != rebound.MatchNone {
+		if time.Now().After(deadline) {
+			t.Fatal("channel handler is still registered")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := rb.Dispatch("invoice.issued", data); !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+}
+
+func TestReactToChannelCtx_block(t *testing.T) {
+	rb := rebound.New(rebound.WithOverflowPolicy(rebound.OverflowBlock))
+	rb.RegisterType("invoice.issued", InvoiceIssued{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan interface{})
+	rb.ReactToChannelCtx(ctx, "invoice.issued", ch)
+
+	done := make(chan error)
+	go func() {
+		done <- rb.Dispatch("invoice.issued", []byte(`{"type":"invoice.issued","InvoiceID":"inv-1"}`))
+	}()
+
+	// the dispatch returns once ctx is done, whether it was blocked sending
+	// or the channel was already unregistered
+	cancel()
+	if err := <-done; err != nil && !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
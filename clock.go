@@ -0,0 +1,74 @@
+package rebound
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and runs timers, such as a fake clock for
+// deterministic timing tests (see WithClock).
+type Clock interface {
+	Now() time.Time
+
+	// AfterFunc calls f once the duration d elapses, unless stopped before,
+	// like time.AfterFunc. The stop function reports whether it stopped the
+	// call, like time.Timer.Stop.
+	AfterFunc(d time.Duration, f func()) (stop func() bool)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) func() bool {
+	return time.AfterFunc(d, f).Stop
+}
+
+// clock returns the Rebound clock, defaulting to the real clock.
+func (r *Rebound) clock() Clock {
+	if r.clk == nil {
+		return realClock{}
+	}
+
+	return r.clk
+}
+
+// withTimeout is like context.WithTimeout, but the timeout is measured by the
+// Rebound clock.
+func (r *Rebound) withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if r.clk == nil {
+		return context.WithTimeout(ctx, d)
+	}
+
+	deadline := r.clk.Now().Add(d)
+	cctx, cancel := context.WithCancelCause(ctx)
+	stop := r.clk.AfterFunc(d, func() {
+		cancel(context.DeadlineExceeded)
+	})
+
+	return timeoutContext{Context: cctx, deadline: deadline}, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// timeoutContext is a context timed out by a Clock. Its error is the cause of
+// its cancellation, so it is context.DeadlineExceeded once timed out.
+type timeoutContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c timeoutContext) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c timeoutContext) Err() error {
+	if c.Context.Err() == nil {
+		return nil
+	}
+
+	return context.Cause(c.Context)
+}
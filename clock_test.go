@@ -0,0 +1,134 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+// fakeClock is a Clock whose time only moves when advanced, firing the timers
+// that are due.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		stopped := !t.stopped
+		t.stopped = true
+		return stopped
+	}
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+
+	var due []func()
+	for _, t := range c.timers {
+		if !t.stopped && !t.at.After(c.now) {
+			t.stopped = true
+			due = append(due, t.f)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, f := range due {
+		f()
+	}
+}
+
+func TestWithClock_timeout(t *testing.T) {
+	clock := newFakeClock()
+	rb := rebound.New(rebound.WithClock(clock))
+
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		switch event.OrderID {
+		case "elapsing":
+			// not yet timed out
+			clock.Advance(30 * time.Second)
+		case "timing-out":
+			clock.Advance(30 * time.Second)
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				t.Errorf("got context error %v, want %v", ctx.Err(), context.DeadlineExceeded)
+			}
+		}
+
+		return nil
+	})
+
+	msgs := []rebound.Message{
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"elapsing"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"timing-out"}`)},
+		{EventName: "order.completed", Data: []byte(`{"OrderID":"remaining"}`)},
+	}
+
+	errs := rb.DispatchBatchDeadline(context.Background(), msgs, time.Minute)
+	want := []error{nil, nil, context.DeadlineExceeded}
+	for i := range want {
+		if !errors.Is(errs[i], want[i]) {
+			t.Errorf("message %d: got error %v, want %v", i, errs[i], want[i])
+		}
+	}
+}
+
+func TestWithClock_audit(t *testing.T) {
+	clock := newFakeClock()
+	start := clock.Now()
+
+	var rec rebound.AuditRecord
+	rb := rebound.New(
+		rebound.WithClock(clock),
+		rebound.WithAudit(func(r rebound.AuditRecord) {
+			rec = r
+		}),
+	)
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		clock.Advance(time.Second)
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !rec.Time.Equal(start) {
+		t.Errorf("got time %v, want %v", rec.Time, start)
+	}
+
+	if rec.Duration != time.Second {
+		t.Errorf("got duration %v, want %v", rec.Duration, time.Second)
+	}
+}
@@ -0,0 +1,207 @@
+// Command reboundgen generates a typed dispatcher from a manifest of events,
+// decoding the events and calling their handlers without reflection.
+//
+// The manifest is a JSON document such as
+//
+//	{
+//		"package": "orders",
+//		"dispatcher": "Dispatcher",
+//		"events": [
+//			{"name": "order.completed", "type": "OrderCompleted"},
+//			{"name": "order.shipped", "type": "OrderShipped", "handler": "Shipped"}
+//		]
+//	}
+//
+// where the event types are declared in the generated package. The generated
+// dispatcher has a handler field per event, named after the event type
+// prefixed with On unless set, and implements rebound.Dispatcher.
+//
+// Usage, typically from a go:generate directive:
+//
+//	//go:generate go run github.com/uudashr/rebound/cmd/reboundgen -manifest events.json -o dispatcher_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"text/template"
+)
+
+// DefaultDispatcher is the default name of the generated dispatcher type.
+const DefaultDispatcher = "Dispatcher"
+
+type manifest struct {
+	Package    string  `json:"package"`
+	Dispatcher string  `json:"dispatcher"`
+	Events     []event `json:"events"`
+}
+
+type event struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Handler string `json:"handler"`
+}
+
+func main() {
+	manifestPath := flag.String("manifest", "", "path of the events manifest")
+	out := flag.String("o", "", "path of the generated file (default stdout)")
+	flag.Parse()
+
+	if err := run(*manifestPath, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "reboundgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(manifestPath, out string) error {
+	if manifestPath == "" {
+		return fmt.Errorf("manifest is required")
+	}
+
+	b, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	var m manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("failed to parse manifest %q: %w", manifestPath, err)
+	}
+
+	src, err := generate(m)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
+
+// generate returns the formatted source of the dispatcher described by m.
+func generate(m manifest) ([]byte, error) {
+	if err := m.normalize(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := dispatcherTemplate.Execute(&buf, m); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// normalize validates the manifest and fills in its defaults.
+func (m *manifest) normalize() error {
+	if !token.IsIdentifier(m.Package) {
+		return fmt.Errorf("invalid package name %q", m.Package)
+	}
+
+	if m.Dispatcher == "" {
+		m.Dispatcher = DefaultDispatcher
+	}
+
+	if !token.IsIdentifier(m.Dispatcher) {
+		return fmt.Errorf("invalid dispatcher name %q", m.Dispatcher)
+	}
+
+	if len(m.Events) == 0 {
+		return fmt.Errorf("manifest has no events")
+	}
+
+	names := make(map[string]bool)
+	handlers := make(map[string]bool)
+	for i := range m.Events {
+		e := &m.Events[i]
+		if e.Name == "" {
+			return fmt.Errorf("event %d: name is empty", i)
+		}
+
+		if names[e.Name] {
+			return fmt.Errorf("event %q is declared more than once", e.Name)
+		}
+
+		if !token.IsIdentifier(e.Type) {
+			return fmt.Errorf("event %q: invalid type %q", e.Name, e.Type)
+		}
+
+		if e.Handler == "" {
+			e.Handler = "On" + e.Type
+		}
+
+		if !token.IsIdentifier(e.Handler) || !token.IsExported(e.Handler) {
+			return fmt.Errorf("event %q: handler %q should be an exported identifier", e.Name, e.Handler)
+		}
+
+		if handlers[e.Handler] {
+			return fmt.Errorf("event %q: handler %q is declared more than once", e.Name, e.Handler)
+		}
+
+		names[e.Name] = true
+		handlers[e.Handler] = true
+	}
+
+	return nil
+}
+
+var dispatcherTemplate = template.Must(template.New("dispatcher").Parse(`// Code generated by reboundgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+
+	"github.com/uudashr/rebound"
+)
+
+// {{.Dispatcher}} dispatches the events to their typed handlers. An event
+// with a nil handler is unhandled.
+type {{.Dispatcher}} struct {
+{{- range .Events}}
+	{{.Handler}} func(event {{.Type}}) error
+{{- end}}
+}
+
+var _ rebound.Dispatcher = (*{{.Dispatcher}})(nil)
+
+// Dispatch handles an event by its name and associated data.
+func (d *{{.Dispatcher}}) Dispatch(eventName string, data []byte) error {
+	switch eventName {
+	case "":
+		return rebound.ErrEmptyName
+{{- range .Events}}
+	case {{printf "%q" .Name}}:
+		if d.{{.Handler}} == nil {
+			break
+		}
+
+		var event {{.Type}}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return rebound.DecodeError{EventName: eventName, Err: err}
+		}
+
+		if err := d.{{.Handler}}(event); err != nil {
+			return rebound.HandlerError{EventName: eventName, Err: err}
+		}
+
+		return nil
+{{- end}}
+	}
+
+	return rebound.NoHandlerError{EventName: eventName}
+}
+`))
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestRun_golden(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "dispatcher_gen.go")
+	if err := run(filepath.Join("testdata", "manifest.json"), out); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "dispatcher.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code does not match %s, got:\n%s", golden, got)
+	}
+}
+
+func TestGenerate_invalid(t *testing.T) {
+	testCases := map[string]manifest{
+		"no package": {
+			Events: []event{{Name: "order.completed", Type: "OrderCompleted"}},
+		},
+		"no events": {
+			Package: "orders",
+		},
+		"empty event name": {
+			Package: "orders",
+			Events:  []event{{Type: "OrderCompleted"}},
+		},
+		"duplicate event name": {
+			Package: "orders",
+			Events: []event{
+				{Name: "order.completed", Type: "OrderCompleted"},
+				{Name: "order.completed", Type: "OrderShipped"},
+			},
+		},
+		"invalid type": {
+			Package: "orders",
+			Events:  []event{{Name: "order.completed", Type: "*OrderCompleted"}},
+		},
+		"unexported handler": {
+			Package: "orders",
+			Events:  []event{{Name: "order.completed", Type: "OrderCompleted", Handler: "completed"}},
+		},
+		"duplicate handler": {
+			Package: "orders",
+			Events: []event{
+				{Name: "order.completed", Type: "OrderCompleted", Handler: "Handle"},
+				{Name: "order.shipped", Type: "OrderShipped", Handler: "Handle"},
+			},
+		},
+	}
+
+	for name, m := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := generate(m); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+)
+
+// Consume dispatches the frames received from in, in a new goroutine, until
+// ctx is done or in is closed. The extract function extracts the event name
+// and data from a frame.
+//
+// The returned channel receives the failures annotated with their frame
+// number, and is closed once consuming stops. It must be drained, as
+// consuming waits for each failure to be received.
+//
+// The frames are dispatched like by DispatchAsync, so RecoverAsync recovers
+// from the panics of their handlers. Consuming runs in its own goroutine even
+// in synchronous mode (see WithSynchronous), as the failures are received
+// while consuming.
+func (r *Rebound) Consume(ctx context.Context, in <-chan []byte, extract func(frame []byte) (name string, data []byte, err error)) <-chan error {
+	errc := make(chan error)
+
+	go func() {
+		defer close(errc)
+
+		for frameNum := 1; ; frameNum++ {
+			var (
+				frame []byte
+				ok    bool
+			)
+
+			select {
+			case frame, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			if err := r.dispatchFrame(ctx, frame, extract); err != nil {
+				select {
+				case errc <- fmt.Errorf("rebound: frame %d: %w", frameNum, err):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return errc
+}
+
+func (r *Rebound) dispatchFrame(ctx context.Context, frame []byte, extract func(frame []byte) (name string, data []byte, err error)) error {
+	name, data, err := extract(frame)
+	if err != nil {
+		return err
+	}
+
+	// the handlers run on the consuming goroutine, so the frames are
+	// dispatched like by DispatchAsync (see RecoverAsync)
+	_, err = r.dispatch(ctx, name, payload{data: data, async: true})
+	return err
+}
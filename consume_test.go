@@ -0,0 +1,90 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestConsume(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	in := make(chan []byte)
+	go func() {
+		defer close(in)
+		for _, frame := range []string{
+			"order.completed\t{\"OrderID\":\"1\"}",
+			"no separator",
+			"order.canceled\t{\"OrderID\":\"2\"}",
+			"order.completed\t{\"OrderID\":\"3\"}",
+		} {
+			in <- []byte(frame)
+		}
+	}()
+
+	var errs []error
+	for err := range rb.Consume(context.Background(), in, parseTabSeparated) {
+		errs = append(errs, err)
+	}
+
+	if want := []string{"1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d errors, want %d: %v", got, want, errs)
+	}
+
+	if got, want := errs[0].Error(), "rebound: frame 2: missing tab separator"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if !errors.Is(errs[1], rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", errs[1], rebound.ErrNoHandler)
+	}
+}
+
+func TestConsume_canceled(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan []byte)
+	errc := rb.Consume(ctx, in, parseTabSeparated)
+
+	cancel()
+
+	// the error channel is closed once consuming stops
+	for err := range errc {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConsume_recoverAsync(t *testing.T) {
+	rb := rebound.New(rebound.WithRecover(rebound.RecoverAsync))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		panic("boom")
+	})
+
+	in := make(chan []byte, 1)
+	in <- []byte("order.completed\t{}")
+	close(in)
+
+	var errs []error
+	for err := range rb.Consume(context.Background(), in, parseTabSeparated) {
+		errs = append(errs, err)
+	}
+
+	var panicErr rebound.PanicError
+	if len(errs) != 1 || !errors.As(errs[0], &panicErr) {
+		t.Errorf("got errors %v, want a %T", errs, panicErr)
+	}
+}
@@ -0,0 +1,68 @@
+package rebound
+
+import "reflect"
+
+// deepCopy returns an addressable deep copy of v, following pointers, slices,
+// maps and interfaces. Unexported struct fields are copied shallowly.
+//
+// Decoded events hold no cycles, so they are not detected.
+func deepCopy(v reflect.Value) reflect.Value {
+	cp := reflect.New(v.Type()).Elem()
+	copyInto(cp, v)
+	return cp
+}
+
+func copyInto(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+
+		ptr := reflect.New(src.Type().Elem())
+		copyInto(ptr.Elem(), src.Elem())
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		dst.Set(deepCopy(src.Elem()))
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyInto(s.Index(i), src.Index(i))
+		}
+
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyInto(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(deepCopy(iter.Key()), deepCopy(iter.Value()))
+		}
+
+		dst.Set(m)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyInto(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,75 @@
+package rebound_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type CartUpdated struct {
+	CartID string
+	Items  []string
+	Meta   map[string]string
+	Owner  *CartOwner
+}
+
+type CartOwner struct {
+	Name string
+}
+
+func TestWithEventCopy(t *testing.T) {
+	testCases := map[string]struct {
+		enabled bool
+		want    []CartUpdated
+	}{
+		"enabled": {
+			enabled: true,
+			want: []CartUpdated{
+				{CartID: "c-1", Items: []string{"apple"}, Meta: map[string]string{"channel": "web"}, Owner: &CartOwner{Name: "alice"}},
+				{CartID: "c-1", Items: []string{"apple"}, Meta: map[string]string{"channel": "web"}, Owner: &CartOwner{Name: "alice"}},
+			},
+		},
+		"disabled": {
+			enabled: false,
+			want: []CartUpdated{
+				{CartID: "c-1", Items: []string{"apple"}, Meta: map[string]string{"channel": "web"}, Owner: &CartOwner{Name: "alice"}},
+				{CartID: "c-1", Items: []string{"mutated"}, Meta: map[string]string{"channel": "mutated"}, Owner: &CartOwner{Name: "mutated"}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithEventCopy(tc.enabled))
+
+			var seen []CartUpdated
+			mutate := func(event CartUpdated) error {
+				seen = append(seen, CartUpdated{
+					CartID: event.CartID,
+					Items:  append([]string(nil), event.Items...),
+					Meta:   map[string]string{"channel": event.Meta["channel"]},
+					Owner:  &CartOwner{Name: event.Owner.Name},
+				})
+
+				event.CartID = "mutated"
+				event.Items[0] = "mutated"
+				event.Meta["channel"] = "mutated"
+				event.Owner.Name = "mutated"
+				return nil
+			}
+
+			rb.ReactTo("cart.updated", mutate)
+			rb.ReactToWithPriority("cart.updated", -1, mutate)
+
+			data := []byte(`{"CartID":"c-1","Items":["apple"],"Meta":{"channel":"web"},"Owner":{"Name":"alice"}}`)
+			if err := rb.Dispatch("cart.updated", data); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(seen, tc.want) {
+				t.Errorf("got %+v, want %+v", seen, tc.want)
+			}
+		})
+	}
+}
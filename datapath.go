@@ -0,0 +1,47 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// extractPayload replaces the payload data by the JSON sub-document at the
+// data path (see WithDataPath).
+func (r *Rebound) extractPayload(eventName string, p *payload) error {
+	data, err := p.bytes(eventName)
+	if err != nil {
+		return err
+	}
+
+	if err := p.checkLimit(eventName); err != nil {
+		return err
+	}
+
+	sub, err := extractPath(data, r.dataPath)
+	if err != nil {
+		return DecodeError{EventName: eventName, Err: err}
+	}
+
+	p.data = sub
+	return nil
+}
+
+// extractPath returns the JSON sub-document of data at the dotted path.
+func extractPath(data []byte, path string) ([]byte, error) {
+	for _, key := range strings.Split(path, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to read data path %q: %w", path, err)
+		}
+
+		raw, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("missing data path %q", path)
+		}
+
+		data = raw
+	}
+
+	return data, nil
+}
@@ -0,0 +1,73 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithDataPath(t *testing.T) {
+	testCases := map[string]struct {
+		path string
+		data string
+	}{
+		"root":   {data: `{"OrderID":"123"}`},
+		"nested": {path: "data", data: `{"data":{"OrderID":"123"}}`},
+		"dotted": {path: "payload.data", data: `{"meta":{"OrderID":"456"},"payload":{"data":{"OrderID":"123"}}}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithDataPath(tc.path))
+
+			var got OrderEvent
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				got = event
+				return nil
+			})
+
+			if err := rb.Dispatch("order.completed", []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := "123"; got.OrderID != want {
+				t.Errorf("got %q, want %q", got.OrderID, want)
+			}
+		})
+	}
+}
+
+func TestWithDataPath_missing(t *testing.T) {
+	rb := rebound.New(rebound.WithDataPath("data"))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+
+	var decodeErr rebound.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("got error %v, want %T", err, decodeErr)
+	}
+}
+
+func TestWithDataPath_polymorphic(t *testing.T) {
+	rb := rebound.New(rebound.WithDataPath("data"))
+	rb.RegisterType("card", CardPayment{})
+
+	var got string
+	rb.ReactTo("payment.received", func(event PaymentMethod) error {
+		got = event.Describe()
+		return nil
+	})
+
+	if err := rb.Dispatch("payment.received", []byte(`{"type":"card","data":{"Last4":"4242"}}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "card 4242"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package rebound
+
+import "sync"
+
+// DeadLetter is an event whose dispatch failed, collected for a dead-letter
+// queue (see WithDeadLetterBatch). Data is the event data as dispatched, so
+// it can be dispatched again.
+type DeadLetter struct {
+	EventName string
+	Data      []byte
+	Err       error
+}
+
+// deadLetterBatch accumulates the dead letters until flushed.
+type deadLetterBatch struct {
+	mu    sync.Mutex
+	size  int
+	flush func(items []DeadLetter) error
+	items []DeadLetter
+}
+
+// add adds the dead letter, flushing the batch once full.
+func (b *deadLetterBatch) add(item DeadLetter) error {
+	b.mu.Lock()
+	b.items = append(b.items, item)
+	full := len(b.items) >= b.size
+	b.mu.Unlock()
+
+	if !full {
+		return nil
+	}
+
+	return b.flushItems()
+}
+
+// flushItems flushes the accumulated dead letters, if any. The flush runs
+// without holding the lock, so it may dispatch the dead letters again. The
+// items are discarded even if flush fails.
+func (b *deadLetterBatch) flushItems() error {
+	b.mu.Lock()
+	items := b.items
+	b.items = nil
+	b.mu.Unlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	return b.flush(items)
+}
+
+// deadLetter adds the failed event to the dead-letter batch, if enabled. The
+// returned error is the error of the flush, if the batch was flushed.
+func (r *Rebound) deadLetter(eventName string, data []byte, err error) error {
+	if r.deadLetters == nil {
+		return nil
+	}
+
+	return r.deadLetters.add(DeadLetter{EventName: eventName, Data: append([]byte(nil), data...), Err: err})
+}
+
+// Shutdown flushes the dead letters accumulated so far (see
+// WithDeadLetterBatch), returning the error of the flush. The dispatches
+// still running are not waited for.
+func (r *Rebound) Shutdown() error {
+	if r.deadLetters == nil {
+		return nil
+	}
+
+	return r.deadLetters.flushItems()
+}
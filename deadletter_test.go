@@ -0,0 +1,146 @@
+package rebound_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithDeadLetterBatch(t *testing.T) {
+	var batches [][]string
+	rb := rebound.New(rebound.WithDeadLetterBatch(2, func(items []rebound.DeadLetter) error {
+		var ids []string
+		for _, item := range items {
+			if !errors.Is(item.Err, rebound.ErrHandler) {
+				t.Errorf("got error %v, want %v", item.Err, rebound.ErrHandler)
+			}
+
+			if item.EventName != "order.completed" {
+				t.Errorf("got event name %q, want %q", item.EventName, "order.completed")
+			}
+
+			ids = append(ids, string(item.Data))
+		}
+
+		batches = append(batches, ids)
+		return nil
+	}))
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "ok" {
+			return nil
+		}
+
+		return errors.New("failed")
+	})
+
+	for _, id := range []string{"1", "ok", "2", "3"} {
+		rb.Dispatch("order.completed", []byte(`{"OrderID":"`+id+`"}`))
+	}
+
+	want := [][]string{{`{"OrderID":"1"}`, `{"OrderID":"2"}`}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Fatalf("got batches %v, want %v", batches, want)
+	}
+
+	if err := rb.Shutdown(); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	want = append(want, []string{`{"OrderID":"3"}`})
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("got batches %v, want %v", batches, want)
+	}
+
+	if err := rb.Shutdown(); err != nil || len(batches) != len(want) {
+		t.Errorf("got error %v and %d batches, want no flush of an empty batch", err, len(batches))
+	}
+}
+
+func TestWithDeadLetterBatch_flushError(t *testing.T) {
+	flushErr := errors.New("flush failed")
+	rb := rebound.New(rebound.WithDeadLetterBatch(1, func(items []rebound.DeadLetter) error {
+		return flushErr
+	}))
+
+	err := rb.Dispatch("order.completed", []byte(`{}`))
+	if !errors.Is(err, rebound.ErrNoHandler) || !errors.Is(err, flushErr) {
+		t.Errorf("got error %v, want %v and %v", err, rebound.ErrNoHandler, flushErr)
+	}
+}
+
+func TestWithDeadLetterBatch_originalData(t *testing.T) {
+	var items []rebound.DeadLetter
+	rb := rebound.New(
+		rebound.WithPreDecode(func(eventName string, data []byte) ([]byte, error) {
+			return bytes.TrimPrefix(data, []byte("v1:")), nil
+		}),
+		rebound.WithDeadLetterBatch(10, func(batch []rebound.DeadLetter) error {
+			items = append(items, batch...)
+			return nil
+		}),
+	)
+
+	var fail bool
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if fail {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	data := []byte(`v1:{"OrderID":"123"}`)
+	fail = true
+	rb.Dispatch("order.completed", data)
+
+	if err := rb.Shutdown(); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(items) != 1 || !bytes.Equal(items[0].Data, data) {
+		t.Fatalf("got dead letters %v, want the data %s", items, data)
+	}
+
+	// the dead letter replays as dispatched
+	fail = false
+	if err := rb.Dispatch(items[0].EventName, items[0].Data); err != nil {
+		t.Errorf("got error %v replaying the dead letter", err)
+	}
+}
+
+func TestWithDeadLetterBatch_replayInFlush(t *testing.T) {
+	var rb *rebound.Rebound
+	var replayed int
+	rb = rebound.New(rebound.WithDeadLetterBatch(1, func(items []rebound.DeadLetter) error {
+		// the replayed dead letters fail again, and are flushed again
+		for _, item := range items {
+			if replayed++; replayed < 3 {
+				rb.Dispatch(item.EventName, item.Data)
+			}
+		}
+
+		return nil
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rb.Dispatch("order.completed", []byte(`{}`))
+		rb.Shutdown()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch blocked replaying in the flush")
+	}
+
+	if got, want := replayed, 3; got != want {
+		t.Errorf("got %d replays, want %d", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package rebound
+
+import "fmt"
+
+// PendingHandlerError indicates that an event declared using Declare has no
+// handler yet. It matches ErrNoHandler, like NoHandlerError.
+type PendingHandlerError struct {
+	EventName string
+}
+
+// Error returns the error message for PendingHandlerError.
+func (e PendingHandlerError) Error() string {
+	return fmt.Sprintf("rebound: event %q is declared but has no handler yet", e.EventName)
+}
+
+// Is reports whether target is ErrNoHandler.
+func (e PendingHandlerError) Is(target error) bool {
+	return target == ErrNoHandler
+}
+
+// Declare declares the names of the expected events, such as for
+// contract-first development. Dispatching a declared event without a handler
+// returns a PendingHandlerError instead of a NoHandlerError.
+func (r *Rebound) Declare(eventNames ...string) {
+	for _, eventName := range eventNames {
+		if eventName == "" {
+			panic("rebound: event name is empty")
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reg.declared == nil {
+		r.reg.declared = make(map[string]bool, len(eventNames))
+	}
+
+	for _, eventName := range eventNames {
+		r.reg.declared[eventName] = true
+	}
+}
+
+func (r *Rebound) isDeclared(eventName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.reg.declared[eventName]
+}
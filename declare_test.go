@@ -0,0 +1,50 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDeclare(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.Declare("order.completed", "order.shipped")
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := rb.Dispatch("order.shipped", []byte(`{}`))
+
+	var pendingErr rebound.PendingHandlerError
+	if !errors.As(err, &pendingErr) {
+		t.Fatalf("got error %v, want %T", err, pendingErr)
+	}
+
+	if got, want := pendingErr.EventName, "order.shipped"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var noHandlerErr rebound.NoHandlerError
+	if errors.As(err, &noHandlerErr) {
+		t.Errorf("got error %v, want no %T", err, noHandlerErr)
+	}
+
+	err = rb.Dispatch("order.canceled", []byte(`{}`))
+	if !errors.As(err, &noHandlerErr) {
+		t.Fatalf("got error %v, want %T", err, noHandlerErr)
+	}
+
+	if errors.As(err, &pendingErr) {
+		t.Errorf("got error %v, want no %T", err, pendingErr)
+	}
+}
+
+func TestPendingHandlerError_Is(t *testing.T) {
+	err := error(rebound.PendingHandlerError{EventName: "order.shipped"})
+	if !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+}
@@ -0,0 +1,65 @@
+package rebound
+
+import "fmt"
+
+// DepthExceededError indicates that the data nests more deeply than allowed
+// (see DepthLimitedDecoder).
+type DepthExceededError struct {
+	MaxDepth int
+	Offset   int
+}
+
+// Error returns the error message for DepthExceededError.
+func (e DepthExceededError) Error() string {
+	return fmt.Sprintf("rebound: data exceeds the maximum depth of %d at offset %d", e.MaxDepth, e.Offset)
+}
+
+// DepthLimitedDecoder returns a decoder rejecting the JSON data nesting
+// objects and arrays deeper than maxDepth with a DepthExceededError, before
+// delegating to inner. The scan stops at the first opening beyond the limit,
+// and malformed data within the limit is left for inner to reject. It panics
+// if maxDepth is not positive.
+func DepthLimitedDecoder(inner Decoder, maxDepth int) Decoder {
+	if maxDepth <= 0 {
+		panic("rebound: maximum depth must be positive")
+	}
+
+	return DecodeFunc(func(data []byte, v interface{}) error {
+		if err := checkDepth(data, maxDepth); err != nil {
+			return err
+		}
+
+		return inner.Decode(data, v)
+	})
+}
+
+// checkDepth checks that the JSON data nests no deeper than maxDepth,
+// skipping the brackets within strings.
+func checkDepth(data []byte, maxDepth int) error {
+	var depth int
+	var inString, escaped bool
+	for i, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > maxDepth {
+				return DepthExceededError{MaxDepth: maxDepth, Offset: i}
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+
+	return nil
+}
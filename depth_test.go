@@ -0,0 +1,75 @@
+package rebound_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDepthLimitedDecoder(t *testing.T) {
+	testCases := map[string]struct {
+		data      string
+		wantDepth bool
+	}{
+		"flat":               {data: `{"OrderID":"123"}`},
+		"at limit":           {data: `{"OrderID":"123","Meta":{"Tags":["a"]}}`},
+		"beyond limit":       {data: `{"OrderID":"123","Meta":{"Tags":[["a"]]}}`, wantDepth: true},
+		"brackets in string": {data: `{"OrderID":"[[[{{{\"]]]"}`},
+		"deeply nested":      {data: `{"OrderID":"123","Meta":` + strings.Repeat("[", 10000) + strings.Repeat("]", 10000) + `}`, wantDepth: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var decoded bool
+			inner := rebound.DecodeFunc(func(data []byte, v interface{}) error {
+				decoded = true
+				return rebound.JSONDecoder.Decode(data, v)
+			})
+
+			var event OrderEvent
+			err := rebound.DepthLimitedDecoder(inner, 3).Decode([]byte(tc.data), &event)
+
+			var depthErr rebound.DepthExceededError
+			if got := errors.As(err, &depthErr); got != tc.wantDepth {
+				t.Fatalf("got error %v, want depth exceeded %t", err, tc.wantDepth)
+			}
+
+			if tc.wantDepth {
+				if decoded {
+					t.Error("expect inner decoder not to be called")
+				}
+
+				if depthErr.MaxDepth != 3 {
+					t.Errorf("got max depth %d, want %d", depthErr.MaxDepth, 3)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+
+			if !decoded {
+				t.Error("expect inner decoder to be called")
+			}
+		})
+	}
+}
+
+func TestDepthLimitedDecoder_dispatch(t *testing.T) {
+	rb := &rebound.Rebound{Decoder: rebound.DepthLimitedDecoder(rebound.JSONDecoder, 1)}
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		t.Error("expect handler not to be called")
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123","Meta":{}}`))
+
+	var depthErr rebound.DepthExceededError
+	if !errors.Is(err, rebound.ErrDecode) || !errors.As(err, &depthErr) {
+		t.Errorf("got error %v, want %v and %T", err, rebound.ErrDecode, depthErr)
+	}
+}
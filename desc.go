@@ -0,0 +1,50 @@
+package rebound
+
+import "fmt"
+
+// ReactToWithDesc registers an event handler for a given event name, like
+// ReactTo, along with a human description of the event, such as for
+// generating an event catalog (see Description and ExportRoutes).
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToWithDesc(eventName, description string, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	h := newHandler(fn, 0)
+	h.desc = description
+	r.addHandler(eventName, h)
+}
+
+// Description returns the description of the event registered using
+// ReactToWithDesc, or false if it has none.
+func (r *Rebound) Description(eventName string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	desc := describe(r.reg.handlers[eventName])
+	return desc, desc != ""
+}
+
+// describe returns the first description of the handlers.
+func describe(hs []*handler) string {
+	for _, h := range hs {
+		if h.desc != "" {
+			return h.desc
+		}
+	}
+
+	return ""
+}
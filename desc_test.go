@@ -0,0 +1,46 @@
+package rebound_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestReactToWithDesc(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactToWithDesc("order.completed", "An order is paid and ready to ship.", func(event OrderEvent) error {
+		return nil
+	})
+
+	rb.ReactTo("order.shipped", func(event OrderShipped) error {
+		return nil
+	})
+
+	desc, ok := rb.Description("order.completed")
+	if !ok {
+		t.Fatal("expect description")
+	}
+
+	if want := "An order is paid and ready to ship."; desc != want {
+		t.Errorf("got %q, want %q", desc, want)
+	}
+
+	if _, ok := rb.Description("order.shipped"); ok {
+		t.Error("expect no description")
+	}
+
+	if _, ok := rb.Description("order.canceled"); ok {
+		t.Error("expect no description")
+	}
+
+	b, err := json.Marshal(rb.ExportRoutes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `[{"eventName":"order.completed","eventType":"rebound_test.OrderEvent","description":"An order is paid and ready to ship."},{"eventName":"order.shipped","eventType":"rebound_test.OrderShipped"}]`
+	if got := string(b); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
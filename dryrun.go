@@ -0,0 +1,16 @@
+package rebound
+
+import "context"
+
+// DryRun checks that an event would be handled, without calling the handlers.
+// It resolves the handler of the event, runs the middlewares, such as the
+// validating ones, and decodes the data into the event type, returning the
+// error a dispatch would return up to the handlers, or nil.
+//
+// The data of an event handled by a handler registered using ReactToFunc is
+// not decoded, so only its routing is checked. A dry run is not reported to
+// the audit, error handler or taps, and does not count as an unhandled event.
+func (r *Rebound) DryRun(eventName string, data []byte) error {
+	_, err := r.routeRecover(context.Background(), eventName, &payload{data: data, dryRun: true})
+	return err
+}
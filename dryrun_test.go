@@ -0,0 +1,87 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDryRun(t *testing.T) {
+	testCases := map[string]struct {
+		eventName string
+		data      string
+		wantErr   error
+	}{
+		"success": {
+			eventName: "order.completed",
+			data:      `{"OrderID":"123"}`,
+		},
+		"decode error": {
+			eventName: "order.completed",
+			data:      `{"OrderID":123}`,
+			wantErr:   rebound.ErrDecode,
+		},
+		"no handler": {
+			eventName: "order.unknown",
+			data:      `{}`,
+			wantErr:   rebound.ErrNoHandler,
+		},
+		"empty name": {
+			data:    `{}`,
+			wantErr: rebound.ErrEmptyName,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithUnhandledTracking())
+
+			var called bool
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				called = true
+				return nil
+			})
+
+			err := rb.DryRun(tc.eventName, []byte(tc.data))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("got error %v, want %v", err, tc.wantErr)
+			}
+
+			if called {
+				t.Error("expect the handler not to be called")
+			}
+
+			if got := rb.UnhandledEvents(); len(got) != 0 {
+				t.Errorf("got unhandled events %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestDryRun_middleware(t *testing.T) {
+	invalidErr := errors.New("invalid event")
+	rb := rebound.New(rebound.WithMiddleware(func(next rebound.DispatchFunc) rebound.DispatchFunc {
+		return func(ctx context.Context, eventName string, data []byte) error {
+			if string(data) == `{}` {
+				return invalidErr
+			}
+
+			return next(ctx, eventName, data)
+		}
+	}))
+
+	rb.ReactToFunc("order.completed", func(data []byte) error {
+		t.Error("expect the handler not to be called")
+		return nil
+	})
+
+	if err := rb.DryRun("order.completed", []byte(`{}`)); !errors.Is(err, invalidErr) {
+		t.Errorf("got error %v, want %v", err, invalidErr)
+	}
+
+	if err := rb.DryRun("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
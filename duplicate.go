@@ -0,0 +1,80 @@
+package rebound
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DuplicatePolicy defines what happens when ReactTo registers a handler for
+// an event that already has a handler.
+type DuplicatePolicy int
+
+const (
+	// DuplicatePanic panics. This is the default.
+	DuplicatePanic DuplicatePolicy = iota
+
+	// DuplicateReplace replaces the existing handlers of the event.
+	DuplicateReplace
+
+	// DuplicateIgnore keeps the existing handlers of the event, and ignores
+	// the new handler.
+	DuplicateIgnore
+
+	// DuplicateError keeps the existing handlers of the event, and records a
+	// DuplicateHandlerError returned by RegistrationErr.
+	DuplicateError
+)
+
+func (p DuplicatePolicy) String() string {
+	switch p {
+	case DuplicatePanic:
+		return "panic"
+	case DuplicateReplace:
+		return "replace"
+	case DuplicateIgnore:
+		return "ignore"
+	case DuplicateError:
+		return "error"
+	default:
+		return fmt.Sprintf("DuplicatePolicy(%d)", int(p))
+	}
+}
+
+// DuplicateHandlerError indicates that a handler was registered for an event
+// that already has a handler.
+type DuplicateHandlerError struct {
+	EventName string
+}
+
+// Error returns the error message for DuplicateHandlerError.
+func (e DuplicateHandlerError) Error() string {
+	return fmt.Sprintf("rebound: event %q already has a handler", e.EventName)
+}
+
+// RegistrationErr returns the DuplicateHandlerError recorded by ReactTo under
+// the DuplicateError policy, joined, or nil if there is none.
+func (r *Rebound) RegistrationErr() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return errors.Join(r.dupErrs...)
+}
+
+// resolveDuplicate applies the duplicate policy to an event that already has
+// a handler, and reports whether the new handler is to be registered. It
+// requires r.mu to be held.
+func (r *Rebound) resolveDuplicate(eventName string) bool {
+	switch r.duplicate {
+	case DuplicateReplace:
+		delete(r.reg.handlers, eventName)
+		delete(r.reg.funcs, eventName)
+		return true
+	case DuplicateIgnore:
+		return false
+	case DuplicateError:
+		r.dupErrs = append(r.dupErrs, DuplicateHandlerError{EventName: eventName})
+		return false
+	default:
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+}
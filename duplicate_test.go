@@ -0,0 +1,117 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithDuplicatePolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policy    rebound.DuplicatePolicy
+		wantPanic bool
+		wantCalls []string
+		wantErr   bool
+	}{
+		"panic": {
+			policy:    rebound.DuplicatePanic,
+			wantPanic: true,
+			wantCalls: []string{"first"},
+		},
+		"replace": {
+			policy:    rebound.DuplicateReplace,
+			wantCalls: []string{"second"},
+		},
+		"ignore": {
+			policy:    rebound.DuplicateIgnore,
+			wantCalls: []string{"first"},
+		},
+		"error": {
+			policy:    rebound.DuplicateError,
+			wantCalls: []string{"first"},
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithDuplicatePolicy(tc.policy))
+
+			var calls []string
+			handler := func(name string) func(event OrderEvent) error {
+				return func(event OrderEvent) error {
+					calls = append(calls, name)
+					return nil
+				}
+			}
+
+			rb.ReactTo("order.completed", handler("first"))
+
+			func() {
+				defer func() {
+					if v := recover(); (v != nil) != tc.wantPanic {
+						t.Errorf("got panic %v, want panic %t", v, tc.wantPanic)
+					}
+				}()
+
+				rb.ReactTo("order.completed", handler("second"))
+			}()
+
+			if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(calls) != len(tc.wantCalls) || calls[0] != tc.wantCalls[0] {
+				t.Errorf("got calls %v, want %v", calls, tc.wantCalls)
+			}
+
+			err := rb.RegistrationErr()
+			var dupErr rebound.DuplicateHandlerError
+			if got := errors.As(err, &dupErr); got != tc.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tc.wantErr)
+			}
+
+			if tc.wantErr && dupErr.EventName != "order.completed" {
+				t.Errorf("got event name %q, want %q", dupErr.EventName, "order.completed")
+			}
+		})
+	}
+}
+
+func TestWithDuplicatePolicy_replaceFunc(t *testing.T) {
+	rb := rebound.New(rebound.WithDuplicatePolicy(rebound.DuplicateReplace))
+	rb.ReactToFunc("order.completed", func(data []byte) error {
+		return errors.New("replaced handler called")
+	})
+
+	var called bool
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		called = true
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Error("expect the replacing handler to be called")
+	}
+}
+
+func TestDuplicatePolicy_String(t *testing.T) {
+	testCases := map[rebound.DuplicatePolicy]string{
+		rebound.DuplicatePanic:      "panic",
+		rebound.DuplicateReplace:    "replace",
+		rebound.DuplicateIgnore:     "ignore",
+		rebound.DuplicateError:      "error",
+		rebound.DuplicatePolicy(42): "DuplicatePolicy(42)",
+	}
+
+	for policy, want := range testCases {
+		if got := policy.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
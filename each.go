@@ -0,0 +1,89 @@
+package rebound
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// ReactToEach registers an event handler for a given event name, whose
+// payload is a JSON array of events. The handler is called once per element,
+// and the errors are joined. A payload holding a single event is handled as
+// a one-element array.
+//
+// The event input parameter of fn should be a struct.
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToEach(eventName string, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	h := newHandler(fn, 0)
+	if h.eventType.Kind() != reflect.Struct {
+		panic(invalidHandler(ReasonEventType, "rebound: fn EventHandler event input parameter should be a struct (got: %v)", h.eventType.Kind()))
+	}
+
+	h.each = true
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	r.addHandler(eventName, h)
+}
+
+// decodeEach decodes the payload into the events of the event type, from a
+// JSON array or a single event.
+func (r *Rebound) decodeEach(eventName string, eventType reflect.Type, ms map[int]func(old []byte) ([]byte, error), p *payload) ([]reflect.Value, error) {
+	if _, err := p.bytes(eventName); err != nil {
+		return nil, err
+	}
+
+	// the array is looked for in the data as decoded, after the pre-decode
+	// step, the migrations and the data path extraction; an extraction error
+	// is left for decodeEvent to report
+	if err := r.prepareData(eventName, ms, p); err != nil {
+		return nil, err
+	}
+
+	data := p.data
+	if r.dataPath != "" {
+		if sub, err := extractPath(data, r.dataPath); err == nil {
+			data = sub
+		}
+	}
+
+	if !isJSONArray(data) {
+		event, err := r.decodeEvent(eventName, eventType, ms, p)
+		if err != nil {
+			return nil, err
+		}
+
+		return []reflect.Value{event}, nil
+	}
+
+	s, err := r.decodeEvent(eventName, reflect.SliceOf(eventType), ms, p)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]reflect.Value, s.Len())
+	for i := range events {
+		events[i] = s.Index(i)
+	}
+
+	return events, nil
+}
+
+func isJSONArray(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '['
+}
@@ -0,0 +1,142 @@
+package rebound_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestReactToEach(t *testing.T) {
+	testCases := map[string]struct {
+		data string
+		want []string
+	}{
+		"array":         {data: `[{"OrderID":"1"},{"OrderID":"2"},{"OrderID":"3"}]`, want: []string{"1", "2", "3"}},
+		"single object": {data: `{"OrderID":"1"}`, want: []string{"1"}},
+		"empty array":   {data: ` []`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := &rebound.Rebound{}
+
+			var got []string
+			rb.ReactToEach("order.completed", func(event OrderEvent) error {
+				got = append(got, event.OrderID)
+				return nil
+			})
+
+			if err := rb.Dispatch("order.completed", []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReactToEach_decodeSteps(t *testing.T) {
+	testCases := map[string]struct {
+		opts []rebound.Option
+		data string
+		want []string
+	}{
+		"data path array": {
+			opts: []rebound.Option{rebound.WithDataPath("data")},
+			data: `{"data":[{"OrderID":"1"},{"OrderID":"2"}]}`,
+			want: []string{"1", "2"},
+		},
+		"data path single object": {
+			opts: []rebound.Option{rebound.WithDataPath("data")},
+			data: `{"data":{"OrderID":"1"}}`,
+			want: []string{"1"},
+		},
+		"pre-decode array": {
+			opts: []rebound.Option{rebound.WithPreDecode(func(eventName string, data []byte) ([]byte, error) {
+				return bytes.TrimPrefix(data, []byte("v1:")), nil
+			})},
+			data: `v1:[{"OrderID":"1"},{"OrderID":"2"}]`,
+			want: []string{"1", "2"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(tc.opts...)
+
+			var got []string
+			rb.ReactToEach("order.completed", func(event OrderEvent) error {
+				got = append(got, event.OrderID)
+				return nil
+			})
+
+			if err := rb.Dispatch("order.completed", []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReactToEach_errors(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	errOne := errors.New("error one")
+	errThree := errors.New("error three")
+
+	var got []string
+	rb.ReactToEach("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		switch event.OrderID {
+		case "1":
+			return errOne
+		case "3":
+			return errThree
+		}
+
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`[{"OrderID":"1"},{"OrderID":"2"},{"OrderID":"3"}]`))
+	if !errors.Is(err, errOne) || !errors.Is(err, errThree) {
+		t.Errorf("got error %v, want both %v and %v", err, errOne, errThree)
+	}
+
+	if want := []string{"1", "2", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReactToEach_decodeError(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactToEach("order.completed", func(event OrderEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`[{"OrderID":"1"},{"OrderID":2}]`))
+	if !errors.Is(err, rebound.ErrDecode) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrDecode)
+	}
+}
+
+func TestReactToEach_interfaceEvent(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb := &rebound.Rebound{}
+	rb.ReactToEach("payment.received", func(event PaymentMethod) error {
+		return nil
+	})
+}
@@ -0,0 +1,139 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ContentTypeHeader is the envelope header selecting the decoder of the event
+// data (see WithContentTypeDecoders).
+const ContentTypeHeader = "Content-Type"
+
+// Envelope is an event as carried by a transport, with its headers.
+type Envelope struct {
+	Name    string
+	Headers map[string]string
+	Data    []byte
+
+	// Key is the message key, such as a Kafka message key, passed to the
+	// handlers that take a key like for DispatchKeyed.
+	Key []byte
+}
+
+// DispatchEnvelope handles an event carried by an envelope.
+//
+// The event name is the envelope name. When it is empty, and an event name
+// header is set (see WithEventNameFromHeader), the name is read from the
+// envelope headers instead.
+//
+// The data is decoded by the decoder registered for the media type of the
+// Content-Type header (see WithContentTypeDecoders), or by the Rebound
+// decoder when the header is absent or its media type unknown.
+//
+// When an event TTL is set (see WithEventTTL), an event older than the TTL is
+// dropped, and an ExpiredEventError is returned without calling the handler.
+//
+// When the content type is required (see WithRequireContentType), an envelope
+// without a Content-Type header is rejected with a MissingContentTypeError.
+func (r *Rebound) DispatchEnvelope(env Envelope) error {
+	if err := r.checkEnvelope(env); err != nil {
+		return r.handleError(r.envelopeName(env), env.Data, err)
+	}
+
+	p := payload{data: env.Data, key: env.Key, headers: env.Headers, dec: r.envelopeDecoder(env)}
+	_, err := r.dispatch(context.Background(), r.envelopeName(env), p)
+	return err
+}
+
+func (r *Rebound) envelopeName(env Envelope) string {
+	if env.Name == "" && r.eventNameHeader != "" {
+		return env.Headers[r.eventNameHeader]
+	}
+
+	return env.Name
+}
+
+// envelopeDecoder returns the decoder registered for the content type of the
+// envelope, nil if none.
+func (r *Rebound) envelopeDecoder(env Envelope) Decoder {
+	if len(r.contentTypeDecoders) == 0 {
+		return nil
+	}
+
+	if contentType, ok := envelopeContentType(env); ok {
+		return r.contentTypeDecoders[mediaType(contentType)]
+	}
+
+	return nil
+}
+
+// envelopeContentType returns the Content-Type header of the envelope,
+// matched case-insensitively, or false if absent.
+func envelopeContentType(env Envelope) (string, bool) {
+	for key, value := range env.Headers {
+		if strings.EqualFold(key, ContentTypeHeader) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// mediaType returns the lower-cased media type of the content type, without
+// its parameters.
+func mediaType(contentType string) string {
+	mt, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// ExpiredEventError indicates that an envelope event is older than the event
+// TTL (see WithEventTTL), and was dropped.
+type ExpiredEventError struct {
+	EventName string
+	Timestamp time.Time
+	TTL       time.Duration
+}
+
+// Error returns the error message for ExpiredEventError.
+func (e ExpiredEventError) Error() string {
+	return fmt.Sprintf("rebound: event %q from %s expired after %s", e.EventName, e.Timestamp.Format(time.RFC3339), e.TTL)
+}
+
+// checkExpired returns an ExpiredEventError if the envelope event is older
+// than the event TTL. An event without a timestamp does not expire.
+func (r *Rebound) checkExpired(env Envelope) error {
+	if r.ttl <= 0 || r.timestampFn == nil {
+		return nil
+	}
+
+	ts := r.timestampFn(env)
+	if ts.IsZero() || r.clock().Now().Sub(ts) <= r.ttl {
+		return nil
+	}
+
+	return ExpiredEventError{EventName: r.envelopeName(env), Timestamp: ts, TTL: r.ttl}
+}
+
+// MissingContentTypeError indicates that an envelope has no Content-Type
+// header while it is required (see WithRequireContentType).
+type MissingContentTypeError struct {
+	EventName string
+}
+
+// Error returns the error message for MissingContentTypeError.
+func (e MissingContentTypeError) Error() string {
+	return fmt.Sprintf("rebound: event %q envelope has no %s header", e.EventName, ContentTypeHeader)
+}
+
+// checkEnvelope returns an error if the envelope is rejected before dispatch.
+func (r *Rebound) checkEnvelope(env Envelope) error {
+	if r.requireContentType {
+		if contentType, ok := envelopeContentType(env); !ok || strings.TrimSpace(contentType) == "" {
+			return MissingContentTypeError{EventName: r.envelopeName(env)}
+		}
+	}
+
+	return r.checkExpired(env)
+}
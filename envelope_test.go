@@ -0,0 +1,248 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDispatchEnvelope(t *testing.T) {
+	testCases := map[string]struct {
+		opts []rebound.Option
+		env  rebound.Envelope
+	}{
+		"name": {
+			env: rebound.Envelope{Name: "order.completed", Data: []byte(`{"OrderID":"123"}`)},
+		},
+		"name from header": {
+			opts: []rebound.Option{rebound.WithEventNameFromHeader("ce-type")},
+			env: rebound.Envelope{
+				Headers: map[string]string{"ce-type": "order.completed"},
+				Data:    []byte(`{"OrderID":"123"}`),
+			},
+		},
+		"name over header": {
+			opts: []rebound.Option{rebound.WithEventNameFromHeader("ce-type")},
+			env: rebound.Envelope{
+				Name:    "order.completed",
+				Headers: map[string]string{"ce-type": "order.cancelled"},
+				Data:    []byte(`{"OrderID":"123"}`),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(tc.opts...)
+
+			var got OrderEvent
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				got = event
+				return nil
+			})
+
+			if err := rb.DispatchEnvelope(tc.env); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := "123"; got.OrderID != want {
+				t.Errorf("got %q, want %q", got.OrderID, want)
+			}
+		})
+	}
+}
+
+func TestDispatchEnvelope_noName(t *testing.T) {
+	rb := rebound.New(rebound.WithEventNameFromHeader("ce-type"))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	env := rebound.Envelope{
+		Headers: map[string]string{"content-type": "application/json"},
+		Data:    []byte(`{"OrderID":"123"}`),
+	}
+
+	if err := rb.DispatchEnvelope(env); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestDispatchEnvelope_contentType(t *testing.T) {
+	// msgpackDecoder stands in for a msgpack decoder
+	msgpackDecoder := rebound.DecodeFunc(func(data []byte, v interface{}) error {
+		v.(*OrderEvent).OrderID = "msgpack:" + string(data)
+		return nil
+	})
+
+	rb := rebound.New(rebound.WithContentTypeDecoders(map[string]rebound.Decoder{
+		"application/json":    rebound.JSONDecoder,
+		"application/msgpack": msgpackDecoder,
+	}))
+
+	var got string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = event.OrderID
+		return nil
+	})
+
+	testCases := map[string]struct {
+		headers map[string]string
+		data    string
+		want    string
+	}{
+		"json": {
+			headers: map[string]string{"Content-Type": "application/json"},
+			data:    `{"OrderID":"123"}`,
+			want:    "123",
+		},
+		"msgpack": {
+			headers: map[string]string{"Content-Type": "application/msgpack"},
+			data:    "123",
+			want:    "msgpack:123",
+		},
+		"header case and parameters": {
+			headers: map[string]string{"content-type": "Application/MsgPack; charset=binary"},
+			data:    "123",
+			want:    "msgpack:123",
+		},
+		"no content type": {
+			data: `{"OrderID":"123"}`,
+			want: "123",
+		},
+		"unknown content type": {
+			headers: map[string]string{"Content-Type": "application/xml"},
+			data:    `{"OrderID":"123"}`,
+			want:    "123",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = ""
+			env := rebound.Envelope{Name: "order.completed", Headers: tc.headers, Data: []byte(tc.data)}
+			if err := rb.DispatchEnvelope(env); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithEventTTL(t *testing.T) {
+	clock := newFakeClock()
+	rb := rebound.New(
+		rebound.WithClock(clock),
+		rebound.WithEventTTL(time.Minute, func(env rebound.Envelope) time.Time {
+			ts, _ := time.Parse(time.RFC3339, env.Headers["Timestamp"])
+			return ts
+		}),
+	)
+
+	var handled []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		handled = append(handled, event.OrderID)
+		return nil
+	})
+
+	now := clock.Now()
+	testCases := map[string]struct {
+		orderID     string
+		timestamp   string
+		wantExpired bool
+	}{
+		"fresh": {
+			orderID:   "fresh",
+			timestamp: now.Add(-30 * time.Second).Format(time.RFC3339),
+		},
+		"expired": {
+			orderID:     "expired",
+			timestamp:   now.Add(-2 * time.Minute).Format(time.RFC3339),
+			wantExpired: true,
+		},
+		"no timestamp": {
+			orderID: "untimed",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			handled = nil
+			err := rb.DispatchEnvelope(rebound.Envelope{
+				Name:    "order.completed",
+				Headers: map[string]string{"Timestamp": tc.timestamp},
+				Data:    []byte(`{"OrderID":"` + tc.orderID + `"}`),
+			})
+
+			var expiredErr rebound.ExpiredEventError
+			if got := errors.As(err, &expiredErr); got != tc.wantExpired {
+				t.Fatalf("got error %v, want expired %t", err, tc.wantExpired)
+			}
+
+			if tc.wantExpired {
+				if len(handled) != 0 {
+					t.Errorf("got handled %v, want none", handled)
+				}
+
+				if expiredErr.TTL != time.Minute {
+					t.Errorf("got TTL %v, want %v", expiredErr.TTL, time.Minute)
+				}
+
+				return
+			}
+
+			if want := []string{tc.orderID}; !reflect.DeepEqual(handled, want) {
+				t.Errorf("got handled %v, want %v", handled, want)
+			}
+		})
+	}
+}
+
+func TestWithRequireContentType(t *testing.T) {
+	testCases := map[string]struct {
+		headers     map[string]string
+		wantMissing bool
+	}{
+		"present": {
+			headers: map[string]string{"content-type": "application/json"},
+		},
+		"missing": {
+			headers:     map[string]string{"Timestamp": "now"},
+			wantMissing: true,
+		},
+		"empty": {
+			headers:     map[string]string{"Content-Type": ""},
+			wantMissing: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithRequireContentType(true))
+
+			var called bool
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				called = true
+				return nil
+			})
+
+			err := rb.DispatchEnvelope(rebound.Envelope{Name: "order.completed", Headers: tc.headers, Data: []byte(`{}`)})
+
+			var missingErr rebound.MissingContentTypeError
+			if got := errors.As(err, &missingErr); got != tc.wantMissing {
+				t.Fatalf("got error %v, want missing content type %t", err, tc.wantMissing)
+			}
+
+			if called == tc.wantMissing {
+				t.Errorf("got called %t, want %t", called, !tc.wantMissing)
+			}
+		})
+	}
+}
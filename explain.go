@@ -0,0 +1,90 @@
+package rebound
+
+import "reflect"
+
+// MatchKind describes how an event name resolves to a handler.
+type MatchKind int
+
+// The match kinds reported by Explain.
+const (
+	MatchNone MatchKind = iota
+	MatchExact
+	MatchWildcard
+	MatchRegexp
+	MatchRemaining
+	MatchAlias
+)
+
+func (k MatchKind) String() string {
+	switch k {
+	case MatchExact:
+		return "exact"
+	case MatchWildcard:
+		return "wildcard"
+	case MatchRegexp:
+		return "regexp"
+	case MatchRemaining:
+		return "remaining"
+	case MatchAlias:
+		return "alias"
+	default:
+		return "none"
+	}
+}
+
+// Routing explains how an event name resolves to a handler.
+type Routing struct {
+	// Key is the event name or pattern the handler is registered with,
+	// empty when the match kind is MatchNone.
+	Key string
+
+	Kind MatchKind
+
+	// EventType is the handler event type, nil when the match kind is
+	// MatchNone or the handler is registered using ReactToFunc.
+	EventType reflect.Type
+}
+
+// Explain reports how an event with the given name would be routed, without
+// dispatching it. An alias (see Alias) is reported as MatchAlias, with the key
+// and the event type of the handler its event name resolves to.
+func (r *Rebound) Explain(eventName string) Routing {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if target, ok := r.reg.aliases[eventName]; ok && !r.hasHandler(eventName) {
+		routing := r.explain(target)
+		if routing.Kind != MatchNone {
+			routing.Kind = MatchAlias
+		}
+
+		return routing
+	}
+
+	return r.explain(eventName)
+}
+
+// explain explains the routing of the event name, ignoring the aliases.
+func (r *Rebound) explain(eventName string) Routing {
+	if _, ok := r.reg.funcs[eventName]; ok {
+		return Routing{Key: eventName, Kind: MatchExact}
+	}
+
+	if hs := r.reg.handlers[eventName]; len(hs) > 0 {
+		return Routing{Key: eventName, Kind: MatchExact, EventType: hs[0].eventType}
+	}
+
+	if ph := r.matchPattern(eventName); ph != nil {
+		kind := MatchWildcard
+		switch {
+		case ph.re != nil:
+			kind = MatchRegexp
+		case ph.remaining:
+			kind = MatchRemaining
+		}
+
+		return Routing{Key: ph.pattern, Kind: kind, EventType: ph.h.eventType}
+	}
+
+	return Routing{Kind: MatchNone}
+}
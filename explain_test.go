@@ -0,0 +1,83 @@
+package rebound_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestExplain(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+	rb.ReactToFunc("order.raw", func(data []byte) error { return nil })
+	rb.ReactToPattern("order.*", func(event OrderShipped) error { return nil })
+	rb.ReactToPattern("order.>", func(event OrderEvent) error { return nil })
+	rb.Alias("order.done", "order.completed")
+	rb.Alias("order.dispatched", "order.shipped")
+	rb.Alias("user.joined", "user.created")
+
+	testCases := map[string]struct {
+		eventName string
+		want      rebound.Routing
+	}{
+		"exact": {
+			eventName: "order.completed",
+			want:      rebound.Routing{Key: "order.completed", Kind: rebound.MatchExact, EventType: reflect.TypeOf(OrderEvent{})},
+		},
+		"raw func": {
+			eventName: "order.raw",
+			want:      rebound.Routing{Key: "order.raw", Kind: rebound.MatchExact},
+		},
+		"wildcard": {
+			eventName: "order.shipped",
+			want:      rebound.Routing{Key: "order.*", Kind: rebound.MatchWildcard, EventType: reflect.TypeOf(OrderShipped{})},
+		},
+		"multi-segment wildcard": {
+			eventName: "order.item.added",
+			want:      rebound.Routing{Key: "order.>", Kind: rebound.MatchWildcard, EventType: reflect.TypeOf(OrderEvent{})},
+		},
+		"alias": {
+			eventName: "order.done",
+			want:      rebound.Routing{Key: "order.completed", Kind: rebound.MatchAlias, EventType: reflect.TypeOf(OrderEvent{})},
+		},
+		"alias to wildcard": {
+			eventName: "order.dispatched",
+			want:      rebound.Routing{Key: "order.*", Kind: rebound.MatchAlias, EventType: reflect.TypeOf(OrderShipped{})},
+		},
+		"alias to none": {
+			eventName: "user.joined",
+			want:      rebound.Routing{Kind: rebound.MatchNone},
+		},
+		"none": {
+			eventName: "user.created",
+			want:      rebound.Routing{Kind: rebound.MatchNone},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := rb.Explain(tc.eventName)
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchKind_String(t *testing.T) {
+	testCases := map[rebound.MatchKind]string{
+		rebound.MatchNone:      "none",
+		rebound.MatchExact:     "exact",
+		rebound.MatchWildcard:  "wildcard",
+		rebound.MatchRegexp:    "regexp",
+		rebound.MatchRemaining: "remaining",
+		rebound.MatchAlias:     "alias",
+	}
+
+	for kind, want := range testCases {
+		if got := kind.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package rebound
+
+import "context"
+
+// Future is the result of a dispatch to await (see DispatchFuture).
+type Future struct {
+	done <-chan struct{}
+	err  *error
+}
+
+// Await waits for the dispatch to finish, returning its error, or the error
+// of ctx if done first. It may be called many times, as the future resolves
+// once.
+func (f Future) Await(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return *f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel closed once the dispatch finished.
+func (f Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// DispatchFuture handles an event by its name and associated data like
+// DispatchAsync, returning a Future to await the result, such as for
+// pipelining the dispatches. The data must not be modified until the future
+// resolves.
+func (r *Rebound) DispatchFuture(eventName string, data []byte) Future {
+	errc := r.DispatchAsync(eventName, data)
+
+	done := make(chan struct{})
+	f := Future{done: done, err: new(error)}
+	go func() {
+		*f.err = <-errc
+		close(done)
+	}()
+
+	return f
+}
@@ -0,0 +1,64 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDispatchFuture(t *testing.T) {
+	handlerErr := errors.New("handler error")
+	rb := rebound.New(rebound.WithSynchronous(false))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "fail" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	ids := []string{"1", "fail", "2", "fail", "3"}
+	futures := make([]rebound.Future, len(ids))
+	for i, id := range ids {
+		futures[i] = rb.DispatchFuture("order.completed", []byte(`{"OrderID":"`+id+`"}`))
+	}
+
+	for i, f := range futures {
+		err := f.Await(context.Background())
+		if want := ids[i] == "fail"; errors.Is(err, handlerErr) != want {
+			t.Errorf("future %d: got error %v, want failed %t", i, err, want)
+		}
+
+		if again := f.Await(context.Background()); !errors.Is(again, err) {
+			t.Errorf("future %d: got error %v on second await, want %v", i, again, err)
+		}
+	}
+}
+
+func TestDispatchFuture_contextDone(t *testing.T) {
+	release := make(chan struct{})
+	rb := rebound.New(rebound.WithSynchronous(false))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		<-release
+		return nil
+	})
+
+	f := rb.DispatchFuture("order.completed", []byte(`{}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := f.Await(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	<-f.Done()
+
+	if err := f.Await(context.Background()); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+}
@@ -0,0 +1,39 @@
+package rebound
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// preDecodePayload replaces the payload data by the output of the pre-decode
+// hook (see WithPreDecode).
+func (r *Rebound) preDecodePayload(eventName string, p *payload) error {
+	data, err := p.bytes(eventName)
+	if err != nil {
+		return err
+	}
+
+	if err := p.checkLimit(eventName); err != nil {
+		return err
+	}
+
+	data, err = r.preDecode(eventName, data)
+	if err != nil {
+		return DecodeError{EventName: eventName, Err: err}
+	}
+
+	p.data = data
+	return nil
+}
+
+// transformEvents calls the event transform (see WithEventTransform) with a
+// pointer to each decoded event.
+func (r *Rebound) transformEvents(eventName string, events []reflect.Value) error {
+	for _, event := range events {
+		if err := r.transform(eventName, event.Addr().Interface()); err != nil {
+			return fmt.Errorf("rebound: failed to transform event %q: %w", eventName, err)
+		}
+	}
+
+	return nil
+}
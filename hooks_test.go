@@ -0,0 +1,196 @@
+package rebound_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithPreDecode(t *testing.T) {
+	prefix := []byte("v1:")
+
+	var gotEventName string
+	rb := rebound.New(rebound.WithPreDecode(func(eventName string, data []byte) ([]byte, error) {
+		gotEventName = eventName
+		if !bytes.HasPrefix(data, prefix) {
+			return nil, errors.New("missing prefix")
+		}
+
+		return bytes.TrimPrefix(data, prefix), nil
+	}))
+
+	var got OrderEvent
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = event
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`v1:{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "123"; got.OrderID != want {
+		t.Errorf("got %q, want %q", got.OrderID, want)
+	}
+
+	if want := "order.completed"; gotEventName != want {
+		t.Errorf("got event name %q, want %q", gotEventName, want)
+	}
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"456"}`))
+	if !errors.Is(err, rebound.ErrDecode) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrDecode)
+	}
+
+	if want := "123"; got.OrderID != want {
+		t.Errorf("got %q, want %q", got.OrderID, want)
+	}
+}
+
+func TestWithPreDecode_originalData(t *testing.T) {
+	data := []byte(`v1:{"OrderID":""}`)
+
+	var gotHandled, gotAudited []byte
+	var gotSize int
+	rb := rebound.New(
+		rebound.WithPreDecode(func(eventName string, data []byte) ([]byte, error) {
+			return bytes.TrimPrefix(data, []byte("v1:")), nil
+		}),
+		rebound.WithErrorHandler(func(eventName string, data []byte, err error) {
+			gotHandled = data
+		}),
+		rebound.WithAudit(func(rec rebound.AuditRecord) {
+			gotAudited = []byte(rec.PayloadHash)
+		}),
+		rebound.WithSizeObserver(func(eventName string, bytes int) {
+			gotSize = bytes
+		}),
+	)
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return errors.New("missing order ID")
+	})
+
+	if err := rb.Dispatch("order.completed", data); !errors.Is(err, rebound.ErrHandler) {
+		t.Fatalf("got error %v, want %v", err, rebound.ErrHandler)
+	}
+
+	if !bytes.Equal(gotHandled, data) {
+		t.Errorf("got handled data %s, want %s", gotHandled, data)
+	}
+
+	sum := sha256.Sum256(data)
+	if got, want := string(gotAudited), hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("got payload hash %q, want %q", got, want)
+	}
+
+	if got, want := gotSize, len(data); got != want {
+		t.Errorf("got size %d, want %d", got, want)
+	}
+}
+
+func TestWithPostHandle(t *testing.T) {
+	type handled struct {
+		eventName string
+		event     interface{}
+	}
+
+	var got []handled
+	rb := rebound.New(rebound.WithPostHandle(func(eventName string, event interface{}) {
+		got = append(got, handled{eventName: eventName, event: event})
+	}))
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "fail" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	rb.ReactToIf("order.shipped", func(event interface{}) bool {
+		return event.(OrderShipped).OrderID != "skip"
+	}, func(event OrderShipped) error {
+		return nil
+	})
+
+	testCases := map[string]struct {
+		eventName string
+		data      string
+		want      []handled
+	}{
+		"success": {
+			eventName: "order.completed",
+			data:      `{"OrderID":"123"}`,
+			want:      []handled{{eventName: "order.completed", event: OrderEvent{OrderID: "123"}}},
+		},
+		"handler error": {eventName: "order.completed", data: `{"OrderID":"fail"}`},
+		"decode error":  {eventName: "order.completed", data: `{`},
+		"no handler":    {eventName: "order.canceled", data: `{}`},
+		"not accepted":  {eventName: "order.shipped", data: `{"OrderID":"skip"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			rb.Dispatch(tc.eventName, []byte(tc.data))
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Errorf("got %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithEventTransform(t *testing.T) {
+	rb := rebound.New(rebound.WithEventTransform(func(eventName string, event interface{}) error {
+		if e, ok := event.(*OrderEvent); ok && e.OrderID == "" {
+			e.OrderID = "default"
+		}
+
+		return nil
+	}))
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	for _, data := range []string{`{}`, `{"OrderID":"123"}`} {
+		if err := rb.Dispatch("order.completed", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(got) != 2 || got[0] != "default" || got[1] != "123" {
+		t.Errorf("got %v, want [default 123]", got)
+	}
+}
+
+func TestWithEventTransform_error(t *testing.T) {
+	transformErr := errors.New("transform error")
+	rb := rebound.New(rebound.WithEventTransform(func(eventName string, event interface{}) error {
+		return transformErr
+	}))
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); !errors.Is(err, transformErr) {
+		t.Errorf("got error %v, want %v", err, transformErr)
+	}
+}
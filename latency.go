@@ -0,0 +1,117 @@
+package rebound
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Percentiles are the approximate percentiles of the handling latency of an
+// event, as tracked when WithLatencyTracking is enabled.
+type Percentiles struct {
+	Count int // the number of tracked dispatches
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// The latency histogram buckets grow exponentially from 1µs, four buckets per
+// doubling, so a percentile is approximated within about 19%. The last bucket
+// holds all the latencies beyond about 1.2 hours.
+const (
+	latencyBucketsPerDoubling = 4
+	latencyBuckets            = 32 * latencyBucketsPerDoubling
+	latencyBase               = time.Microsecond
+)
+
+// latencyHistogram is a fixed-bucket histogram of latencies.
+type latencyHistogram struct {
+	counts [latencyBuckets]uint64
+	total  uint64
+}
+
+func latencyBucket(d time.Duration) int {
+	if d <= latencyBase {
+		return 0
+	}
+
+	i := int(math.Ceil(math.Log2(float64(d)/float64(latencyBase)) * latencyBucketsPerDoubling))
+	if i >= latencyBuckets {
+		return latencyBuckets - 1
+	}
+
+	return i
+}
+
+// latencyBucketBound returns the upper bound of the bucket.
+func latencyBucketBound(i int) time.Duration {
+	return time.Duration(float64(latencyBase) * math.Exp2(float64(i)/latencyBucketsPerDoubling))
+}
+
+func (h *latencyHistogram) add(d time.Duration) {
+	h.counts[latencyBucket(d)]++
+	h.total++
+}
+
+// percentile returns the upper bound of the bucket holding the p percentile,
+// p being in (0, 1].
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	rank := uint64(math.Ceil(p * float64(h.total)))
+	var seen uint64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			return latencyBucketBound(i)
+		}
+	}
+
+	return latencyBucketBound(latencyBuckets - 1)
+}
+
+// latencies tracks the latency histograms by event name.
+type latencies struct {
+	mu     sync.Mutex
+	events map[string]*latencyHistogram
+}
+
+func (l *latencies) add(eventName string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.events[eventName]
+	if !ok {
+		if l.events == nil {
+			l.events = make(map[string]*latencyHistogram)
+		}
+
+		h = &latencyHistogram{}
+		l.events[eventName] = h
+	}
+
+	h.add(d)
+}
+
+// Latencies returns the approximate percentiles of the handling latency of
+// the event, measured from the start of the dispatch until the handlers
+// return, as tracked when WithLatencyTracking is enabled. The dispatches not
+// calling any handler are not tracked.
+func (r *Rebound) Latencies(eventName string) Percentiles {
+	if r.latencies == nil {
+		return Percentiles{}
+	}
+
+	r.latencies.mu.Lock()
+	defer r.latencies.mu.Unlock()
+
+	h, ok := r.latencies.events[eventName]
+	if !ok {
+		return Percentiles{}
+	}
+
+	return Percentiles{
+		Count: int(h.total),
+		P50:   h.percentile(0.50),
+		P95:   h.percentile(0.95),
+		P99:   h.percentile(0.99),
+	}
+}
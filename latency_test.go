@@ -0,0 +1,59 @@
+package rebound_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithLatencyTracking(t *testing.T) {
+	clock := newFakeClock()
+	rb := rebound.New(rebound.WithLatencyTracking(), rebound.WithClock(clock))
+
+	type SlowEvent struct {
+		Latency time.Duration
+	}
+
+	rb.ReactTo("report.generated", func(event SlowEvent) error {
+		clock.Advance(event.Latency)
+		return nil
+	})
+
+	for i := 1; i <= 100; i++ {
+		data, err := json.Marshal(SlowEvent{Latency: time.Duration(i) * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := rb.Dispatch("report.generated", data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got := rb.Latencies("report.generated")
+	if got.Count != 100 {
+		t.Errorf("got count %d, want 100", got.Count)
+	}
+
+	testCases := map[string]struct {
+		got  time.Duration
+		want time.Duration
+	}{
+		"p50": {got: got.P50, want: 50 * time.Millisecond},
+		"p95": {got: got.P95, want: 95 * time.Millisecond},
+		"p99": {got: got.P99, want: 99 * time.Millisecond},
+	}
+
+	for name, tc := range testCases {
+		// the percentiles are bucket bounds, within 20% above the exact value
+		if tc.got < tc.want || tc.got > tc.want*12/10 {
+			t.Errorf("%s: got %v, want about %v", name, tc.got, tc.want)
+		}
+	}
+
+	if got := rb.Latencies("report.unknown"); got != (rebound.Percentiles{}) {
+		t.Errorf("got %+v, want zero percentiles", got)
+	}
+}
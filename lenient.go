@@ -0,0 +1,139 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LenientJSONDecoder is a JSON decoder decoding the fields of a struct one by
+// one, so a field failing to decode does not prevent decoding the others. It
+// returns a PartialDecodeError listing the fields that failed. With
+// WithPartialDecode, the handlers are still called with the partially
+// decoded event.
+//
+// The fields are matched like encoding/json does, by their json tag name or
+// their name, case-insensitively, including the fields of the embedded
+// structs. The values other than structs are decoded as a whole.
+var LenientJSONDecoder Decoder = lenientJSONDecoder{}
+
+// FieldError is the error of a field failing to decode.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns the error message for FieldError.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("field %q: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the decoding error of the field.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// PartialDecodeError indicates that some fields of the event failed to decode,
+// while the other fields are decoded.
+type PartialDecodeError struct {
+	Fields []FieldError
+}
+
+// Error returns the error message for PartialDecodeError.
+func (e PartialDecodeError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, fe := range e.Fields {
+		fields[i] = fe.Error()
+	}
+
+	return fmt.Sprintf("rebound: %d fields failed to decode: %s", len(e.Fields), strings.Join(fields, "; "))
+}
+
+// Unwrap returns the errors of the fields.
+func (e PartialDecodeError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, fe := range e.Fields {
+		errs[i] = fe
+	}
+
+	return errs
+}
+
+type lenientJSONDecoder struct{}
+
+func (lenientJSONDecoder) Decode(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return json.Unmarshal(data, v)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var errs []FieldError
+	decodeFields(fields, rv.Elem(), &errs)
+	if len(errs) > 0 {
+		return PartialDecodeError{Fields: errs}
+	}
+
+	return nil
+}
+
+// decodeFields decodes the fields of the struct value sv, collecting the
+// errors of the fields failing to decode.
+func decodeFields(fields map[string]json.RawMessage, sv reflect.Value, errs *[]FieldError) {
+	t := sv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			decodeFields(fields, sv.Field(i), errs)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		raw, ok := lookupField(fields, name)
+		if !ok {
+			continue
+		}
+
+		ptr := reflect.New(f.Type)
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+			*errs = append(*errs, FieldError{Field: name, Err: err})
+			continue
+		}
+
+		sv.Field(i).Set(ptr.Elem())
+	}
+}
+
+// lookupField returns the raw value of the field, preferring an exact match
+// of its name over a case-insensitive one.
+func lookupField(fields map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := fields[name]; ok {
+		return raw, true
+	}
+
+	for key, raw := range fields {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,78 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type OrderPlaced struct {
+	OrderID  string `json:"order_id"`
+	Quantity int
+	Note     string
+	OrderMeta
+}
+
+type OrderMeta struct {
+	Source string `json:"source"`
+}
+
+func TestLenientJSONDecoder(t *testing.T) {
+	var event OrderPlaced
+	err := rebound.LenientJSONDecoder.Decode([]byte(`{"order_id":"123","quantity":"two","note":"gift","source":"web"}`), &event)
+
+	var partialErr rebound.PartialDecodeError
+	if !errors.As(err, &partialErr) {
+		t.Fatalf("got error %v, want %T", err, partialErr)
+	}
+
+	if len(partialErr.Fields) != 1 || partialErr.Fields[0].Field != "Quantity" {
+		t.Errorf("got failed fields %v, want Quantity only", partialErr.Fields)
+	}
+
+	want := OrderPlaced{OrderID: "123", Note: "gift", OrderMeta: OrderMeta{Source: "web"}}
+	if event != want {
+		t.Errorf("got event %+v, want %+v", event, want)
+	}
+}
+
+func TestWithPartialDecode(t *testing.T) {
+	testCases := map[string]struct {
+		partial    bool
+		wantCalled bool
+	}{
+		"default": {},
+		"partial": {
+			partial:    true,
+			wantCalled: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithPartialDecode(tc.partial))
+			rb.Decoder = rebound.LenientJSONDecoder
+
+			var got *OrderPlaced
+			rb.ReactTo("order.placed", func(event OrderPlaced) error {
+				got = &event
+				return nil
+			})
+
+			err := rb.Dispatch("order.placed", []byte(`{"order_id":"123","Quantity":true}`))
+			var partialErr rebound.PartialDecodeError
+			if !errors.Is(err, rebound.ErrDecode) || !errors.As(err, &partialErr) {
+				t.Errorf("got error %v, want %T", err, partialErr)
+			}
+
+			if (got != nil) != tc.wantCalled {
+				t.Fatalf("got called %t, want %t", got != nil, tc.wantCalled)
+			}
+
+			if got != nil && got.OrderID != "123" {
+				t.Errorf("got order ID %q, want %q", got.OrderID, "123")
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ConcurrencyLimitError indicates that a handler registered using
+// ReactToWithConcurrencyLimit already runs its maximum number of concurrent
+// dispatches, when failing fast (see WithConcurrencyLimitFailFast).
+type ConcurrencyLimitError struct {
+	EventName string
+	Limit     int
+}
+
+// Error returns the error message for ConcurrencyLimitError.
+func (e ConcurrencyLimitError) Error() string {
+	return fmt.Sprintf("rebound: handler of event %q reached its concurrency limit %d", e.EventName, e.Limit)
+}
+
+// ReactToWithConcurrencyLimit registers an event handler for a given event
+// name, like ReactTo, that handles at most max events at a time. The
+// dispatches beyond max wait for a running one to finish, or their context to
+// be done, unless failing fast (see WithConcurrencyLimitFailFast).
+// It panics if the event already has a handler, or if max is not positive.
+func (r *Rebound) ReactToWithConcurrencyLimit(eventName string, max int, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	if max <= 0 {
+		panic(fmt.Sprintf("rebound: concurrency limit %d is not positive", max))
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	h := newHandler(fn, 0)
+	h.sem = make(chan struct{}, max)
+	r.addHandler(eventName, h)
+}
+
+// acquireGlobal acquires a slot of the global concurrency limit (see
+// WithGlobalConcurrency), if any, waiting for one until ctx is done. The
+// returned function releases the slot.
+func (r *Rebound) acquireGlobal(ctx context.Context) (release func(), err error) {
+	if r.globalSem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case r.globalSem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return func() {
+		<-r.globalSem
+	}, nil
+}
+
+// callLimited calls the handler like callHandler, within the concurrency
+// limits of the handler and the Rebound, if any. A handler called once (see
+// ReactToOnce) fires only once the limits are acquired, so a dispatch failing
+// to acquire them leaves it registered. It reports whether the handler was
+// called.
+func (r *Rebound) callLimited(ctx context.Context, eventName string, h *handler, p *payload, event reflect.Value, recovering bool) (bool, error) {
+	if err := h.waitReady(ctx); err != nil {
+		return false, err
+	}
+
+	release, err := r.acquireGlobal(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	if h.sem != nil {
+		if r.limitFailFast {
+			select {
+			case h.sem <- struct{}{}:
+			default:
+				return false, ConcurrencyLimitError{EventName: eventName, Limit: cap(h.sem)}
+			}
+		} else {
+			select {
+			case h.sem <- struct{}{}:
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		}
+
+		defer func() {
+			<-h.sem
+		}()
+	}
+
+	if !r.fire(eventName, h) {
+		return false, nil
+	}
+
+	return true, r.callHandler(ctx, eventName, h, p, event, recovering)
+}
@@ -0,0 +1,255 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestReactToWithConcurrencyLimit(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxIn    int
+	)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	rb.ReactToWithConcurrencyLimit("order.completed", 1, func(event OrderEvent) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	<-started
+	select {
+	case <-started:
+		t.Error("expect the second dispatch to wait for the first one")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	wg.Wait()
+
+	if maxIn != 1 {
+		t.Errorf("got %d handlers in flight, want 1", maxIn)
+	}
+}
+
+func TestReactToWithConcurrencyLimit_failFast(t *testing.T) {
+	rb := rebound.New(rebound.WithConcurrencyLimitFailFast(true))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	rb.ReactToWithConcurrencyLimit("order.completed", 1, func(event OrderEvent) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- rb.Dispatch("order.completed", []byte(`{}`))
+	}()
+
+	<-started
+	err := rb.Dispatch("order.completed", []byte(`{}`))
+
+	var limitErr rebound.ConcurrencyLimitError
+	if !errors.As(err, &limitErr) {
+		t.Errorf("got error %v, want %T", err, limitErr)
+	}
+
+	if limitErr.Limit != 1 {
+		t.Errorf("got limit %d, want 1", limitErr.Limit)
+	}
+
+	close(release)
+	if err := <-errc; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReactToWithConcurrencyLimit_invalidLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb := &rebound.Rebound{}
+	rb.ReactToWithConcurrencyLimit("order.completed", 0, func(event OrderEvent) error {
+		return nil
+	})
+}
+
+func TestWithGlobalConcurrency(t *testing.T) {
+	const limit = 3
+	rb := rebound.New(rebound.WithSynchronous(false), rebound.WithGlobalConcurrency(limit))
+
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxIn    int
+	)
+
+	handle := func() error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return nil
+	}
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return handle()
+	})
+
+	rb.ReactToFunc("order.shipped", func(data []byte) error {
+		return handle()
+	})
+
+	var results []<-chan error
+	for i := 0; i < 50; i++ {
+		results = append(results, rb.DispatchAsync("order.completed", []byte(`{}`)))
+		results = append(results, rb.DispatchAsync("order.shipped", []byte(`{}`)))
+	}
+
+	for _, errc := range results {
+		if err := <-errc; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if maxIn > limit {
+		t.Errorf("got %d handlers in flight, want at most %d", maxIn, limit)
+	}
+
+	if maxIn < 2 {
+		t.Errorf("got %d handlers in flight, want concurrent handlers", maxIn)
+	}
+}
+
+// occupySlot keeps the only slot of the global concurrency limit of rb busy
+// until the returned function is called.
+func occupySlot(t *testing.T, rb *rebound.Rebound) (release func()) {
+	t.Helper()
+
+	started, done := make(chan struct{}), make(chan struct{})
+	rb.ReactToFunc("slot.busy", func(data []byte) error {
+		close(started)
+		<-done
+		return nil
+	})
+
+	errc := rb.DispatchAsync("slot.busy", nil)
+	<-started
+
+	return func() {
+		close(done)
+		if err := <-errc; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+// dispatchCanceled dispatches the event, canceled while waiting for a slot.
+func dispatchCanceled(t *testing.T, rb *rebound.Rebound, eventName string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := rb.DispatchContext(ctx, eventName, []byte(`{}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithGlobalConcurrency_once(t *testing.T) {
+	rb := rebound.New(rebound.WithSynchronous(false), rebound.WithGlobalConcurrency(1))
+
+	var calls int
+	rb.ReactToOnce("order.completed", func(event OrderEvent) error {
+		calls++
+		return nil
+	})
+
+	release := occupySlot(t, rb)
+	dispatchCanceled(t, rb, "order.completed")
+	release()
+
+	// the once handler is still registered, as it was not called
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := calls, 1; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestWithGlobalConcurrency_waitFor(t *testing.T) {
+	rb := rebound.New(rebound.WithSynchronous(false), rebound.WithGlobalConcurrency(1))
+	release := occupySlot(t, rb)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := rb.WaitFor(ctx, "startup.completed")
+		errc <- err
+	}()
+
+	for rb.Explain("startup.completed").Kind == rebound.MatchNone {
+		runtime.Gosched()
+	}
+
+	dispatchCanceled(t, rb, "startup.completed")
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitFor did not return")
+	}
+}
@@ -0,0 +1,51 @@
+package rebound
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerKey is the context key of the logger put by the middleware of
+// WithContextLogger.
+type loggerKey struct{}
+
+// contextLogger returns a middleware putting the logger built for each event
+// into the dispatch context.
+func contextLogger(build func(eventName string) *slog.Logger) Middleware {
+	return func(next DispatchFunc) DispatchFunc {
+		return func(ctx context.Context, eventName string, data []byte) error {
+			if logger := build(eventName); logger != nil {
+				ctx = context.WithValue(ctx, loggerKey{}, logger)
+			}
+
+			return next(ctx, eventName, data)
+		}
+	}
+}
+
+// LoggerFromContext returns the logger put into the handler context by the
+// middleware of WithContextLogger, or slog.Default() if there is none.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// logDispatch logs the start of the dispatch, and returns a function logging
+// its finish or error.
+func (r *Rebound) logDispatch(ctx context.Context, eventName string) func(err error) {
+	start := r.clock().Now()
+	r.slog.LogAttrs(ctx, slog.LevelDebug, "dispatch started", slog.String("event", eventName))
+
+	return func(err error) {
+		duration := slog.Duration("duration", r.clock().Now().Sub(start))
+		if err != nil {
+			r.slog.LogAttrs(ctx, slog.LevelError, "dispatch failed", slog.String("event", eventName), duration, slog.Any("error", err))
+			return
+		}
+
+		r.slog.LogAttrs(ctx, slog.LevelDebug, "dispatch finished", slog.String("event", eventName), duration)
+	}
+}
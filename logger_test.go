@@ -0,0 +1,141 @@
+package rebound_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&buf, nil))
+
+	rb := rebound.New(rebound.WithContextLogger(func(eventName string) *slog.Logger {
+		return base.With("event", eventName)
+	}))
+
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		rebound.LoggerFromContext(ctx).Info("handling", "order", event.OrderID)
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"msg=handling", "event=order.completed", "order=123"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got log %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestLoggerFromContext_default(t *testing.T) {
+	if got := rebound.LoggerFromContext(context.Background()); got != slog.Default() {
+		t.Errorf("got logger %v, want the default logger", got)
+	}
+}
+
+// recordHandler is a slog.Handler capturing the records.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return true
+}
+
+func (h *recordHandler) Handle(ctx context.Context, rec slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, rec)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordHandler) WithGroup(name string) slog.Handler {
+	return h
+}
+
+func recordAttrs(rec slog.Record) map[string]slog.Value {
+	attrs := make(map[string]slog.Value)
+	rec.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+
+	return attrs
+}
+
+func TestWithSlog(t *testing.T) {
+	clock := newFakeClock()
+	h := &recordHandler{}
+	rb := rebound.New(rebound.WithSlog(slog.New(h)), rebound.WithClock(clock))
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		clock.Advance(time.Second)
+		if event.OrderID == "fail" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"fail"}`)); !errors.Is(err, handlerErr) {
+		t.Fatalf("got error %v, want %v", err, handlerErr)
+	}
+
+	want := []struct {
+		level    slog.Level
+		msg      string
+		duration bool
+		err      bool
+	}{
+		{level: slog.LevelDebug, msg: "dispatch started"},
+		{level: slog.LevelDebug, msg: "dispatch finished", duration: true},
+		{level: slog.LevelDebug, msg: "dispatch started"},
+		{level: slog.LevelError, msg: "dispatch failed", duration: true, err: true},
+	}
+
+	if len(h.records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(h.records), len(want))
+	}
+
+	for i, w := range want {
+		rec := h.records[i]
+		if rec.Level != w.level || rec.Message != w.msg {
+			t.Errorf("record %d: got %v %q, want %v %q", i, rec.Level, rec.Message, w.level, w.msg)
+		}
+
+		attrs := recordAttrs(rec)
+		if got := attrs["event"].String(); got != "order.completed" {
+			t.Errorf("record %d: got event %q, want %q", i, got, "order.completed")
+		}
+
+		if got, ok := attrs["duration"]; ok != w.duration || (ok && got.Duration() != time.Second) {
+			t.Errorf("record %d: got duration %v, want %t", i, got, w.duration)
+		}
+
+		if got, ok := attrs["error"]; ok != w.err || (ok && !errors.Is(got.Any().(error), handlerErr)) {
+			t.Errorf("record %d: got error %v, want %t", i, got, w.err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package rebound
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// InMemoryMetrics counts the dispatches and their errors by event name, fed
+// with the audit records:
+//
+//	m := &rebound.InMemoryMetrics{}
+//	rb := rebound.New(rebound.WithAudit(m.Record))
+//
+// The zero value is ready to use, and it is safe for concurrent use.
+type InMemoryMetrics struct {
+	mu         sync.Mutex
+	dispatches map[string]uint64
+	errors     map[string]uint64
+}
+
+// Record counts the dispatch described by the audit record.
+func (m *InMemoryMetrics) Record(rec AuditRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dispatches == nil {
+		m.dispatches = make(map[string]uint64)
+		m.errors = make(map[string]uint64)
+	}
+
+	m.dispatches[rec.EventName]++
+	if rec.Err != nil {
+		m.errors[rec.EventName]++
+	}
+}
+
+// Dispatches returns the number of dispatches of the event.
+func (m *InMemoryMetrics) Dispatches(eventName string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.dispatches[eventName]
+}
+
+// Errors returns the number of failed dispatches of the event.
+func (m *InMemoryMetrics) Errors(eventName string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.errors[eventName]
+}
+
+// WriteText writes the counters in the Prometheus text exposition format,
+// such as for a metrics endpoint, sorted by event name.
+func (m *InMemoryMetrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	writeCounter(bw, "rebound_dispatches_total", "The number of dispatched events.", m.dispatches)
+	writeCounter(bw, "rebound_dispatch_errors_total", "The number of dispatched events that failed.", m.errors)
+	return bw.Flush()
+}
+
+func writeCounter(w *bufio.Writer, name, help string, counts map[string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+
+	eventNames := make([]string, 0, len(counts))
+	for eventName := range counts {
+		eventNames = append(eventNames, eventName)
+	}
+
+	sort.Strings(eventNames)
+	for _, eventName := range eventNames {
+		fmt.Fprintf(w, "%s{event=\"%s\"} %d\n", name, escapeLabel(eventName), counts[eventName])
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a label value of the text exposition format.
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,57 @@
+package rebound_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestInMemoryMetrics_WriteText(t *testing.T) {
+	m := &rebound.InMemoryMetrics{}
+	rb := rebound.New(rebound.WithAudit(m.Record))
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		if event.OrderID == "fail" {
+			return errors.New("handler error")
+		}
+
+		return nil
+	})
+
+	rb.ReactTo(`invoice."issued"`, func(event InvoiceIssued) error {
+		return nil
+	})
+
+	for _, data := range []string{`{"OrderID":"1"}`, `{"OrderID":"fail"}`, `{"OrderID":"2"}`} {
+		rb.Dispatch("order.completed", []byte(data))
+	}
+
+	rb.Dispatch(`invoice."issued"`, []byte(`{}`))
+
+	var sb strings.Builder
+	if err := m.WriteText(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `# HELP rebound_dispatches_total The number of dispatched events.
+# TYPE rebound_dispatches_total counter
+rebound_dispatches_total{event="invoice.\"issued\""} 1
+rebound_dispatches_total{event="order.completed"} 3
+# HELP rebound_dispatch_errors_total The number of dispatched events that failed.
+# TYPE rebound_dispatch_errors_total counter
+rebound_dispatch_errors_total{event="order.completed"} 1
+`
+	if got := sb.String(); got != want {
+		t.Errorf("got text:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := m.Dispatches("order.completed"); got != 3 {
+		t.Errorf("got %d dispatches, want 3", got)
+	}
+
+	if got := m.Errors("order.completed"); got != 1 {
+		t.Errorf("got %d errors, want 1", got)
+	}
+}
@@ -0,0 +1,40 @@
+package rebound
+
+import "context"
+
+// DispatchFunc handles an event by its name and associated data.
+type DispatchFunc func(ctx context.Context, eventName string, data []byte) error
+
+// Middleware wraps a DispatchFunc to run logic around the event dispatch,
+// with access to the raw event data before it is decoded. A middleware may
+// short-circuit the dispatch by returning without calling next.
+type Middleware func(next DispatchFunc) DispatchFunc
+
+// routeMiddlewares routes the event through the middlewares.
+func (r *Rebound) routeMiddlewares(ctx context.Context, eventName string, p *payload) (ran bool, err error) {
+	if r.maxPayloadSize > 0 {
+		p.setLimit(r.maxPayloadSize)
+	}
+
+	data, err := p.bytes(eventName)
+	if err == nil {
+		err = p.checkLimit(eventName)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	next := func(ctx context.Context, eventName string, data []byte) error {
+		var err error
+		ran, err = r.route(ctx, eventName, &payload{data: data, key: p.key, dec: p.dec, async: p.async, dryRun: p.dryRun})
+		return err
+	}
+
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		next = r.middlewares[i](next)
+	}
+
+	err = next(ctx, eventName, data)
+	return ran, err
+}
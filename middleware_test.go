@@ -0,0 +1,67 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) rebound.Middleware {
+		return func(next rebound.DispatchFunc) rebound.DispatchFunc {
+			return func(ctx context.Context, eventName string, data []byte) error {
+				calls = append(calls, name+" "+eventName+" "+string(data))
+				return next(ctx, eventName, data)
+			}
+		}
+	}
+
+	rb := rebound.New(rebound.WithMiddleware(trace("first"), trace("second")))
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		calls = append(calls, "handler "+event.OrderID)
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`first order.completed {"OrderID":"123"}`,
+		`second order.completed {"OrderID":"123"}`,
+		"handler 123",
+	}
+
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+}
+
+func TestWithMiddleware_shortCircuit(t *testing.T) {
+	rejectErr := errors.New("rejected")
+	rb := rebound.New(rebound.WithMiddleware(func(next rebound.DispatchFunc) rebound.DispatchFunc {
+		return func(ctx context.Context, eventName string, data []byte) error {
+			return rejectErr
+		}
+	}))
+
+	var called bool
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		called = true
+		return nil
+	})
+
+	ran, err := rb.TryDispatch(context.Background(), "order.completed", []byte(`{"OrderID":"123"}`))
+	if got, want := err, rejectErr; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if ran || called {
+		t.Error("got handler called, want not called")
+	}
+}
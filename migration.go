@@ -0,0 +1,107 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultVersionField is the default JSON field holding the payload version.
+const DefaultVersionField = "version"
+
+// RegisterMigration registers a function upgrading the payload of an event
+// from the given version to the next one.
+//
+// On dispatch, the version is read from the version field of the JSON payload
+// (see WithVersionField). The migrations are chained from that version until
+// there is no migration for the resulting version, then the result is decoded
+// into the event type. A payload without the version field is not migrated.
+// It panics if the event already has a migration from the given version.
+func (r *Rebound) RegisterMigration(eventName string, fromVersion int, fn func(old []byte) ([]byte, error)) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prev := r.reg.migrations[eventName]
+	if _, exists := prev[fromVersion]; exists {
+		panic(fmt.Sprintf("rebound: event %q already has a migration from version %d", eventName, fromVersion))
+	}
+
+	// copy on write, dispatches and snapshots may hold the previous one
+	ms := make(map[int]func([]byte) ([]byte, error), len(prev)+1)
+	for version, fn := range prev {
+		ms[version] = fn
+	}
+
+	ms[fromVersion] = fn
+
+	if r.reg.migrations == nil {
+		r.reg.migrations = make(map[string]map[int]func([]byte) ([]byte, error))
+	}
+
+	r.reg.migrations[eventName] = ms
+}
+
+// migratePayload reads the payload data and upgrades it to the latest version
+// of the event.
+func (r *Rebound) migratePayload(eventName string, ms map[int]func(old []byte) ([]byte, error), p *payload) error {
+	data, err := p.bytes(eventName)
+	if err != nil {
+		return err
+	}
+
+	if err := p.checkLimit(eventName); err != nil {
+		return err
+	}
+
+	p.data, err = r.migrate(eventName, ms, data)
+	return err
+}
+
+// migrate upgrades the data to the latest version of the event.
+func (r *Rebound) migrate(eventName string, ms map[int]func(old []byte) ([]byte, error), data []byte) ([]byte, error) {
+	version, ok, err := r.payloadVersion(eventName, data)
+	if err != nil || !ok {
+		return data, err
+	}
+
+	for fn := ms[version]; fn != nil; fn = ms[version] {
+		data, err = fn(data)
+		if err != nil {
+			return nil, fmt.Errorf("rebound: failed to migrate event %q from version %d: %w", eventName, version, err)
+		}
+
+		version++
+	}
+
+	return data, nil
+}
+
+func (r *Rebound) payloadVersion(eventName string, data []byte) (version int, ok bool, err error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, false, DecodeError{EventName: eventName, Err: fmt.Errorf("failed to read payload version: %w", err)}
+	}
+
+	field := r.versionField
+	if field == "" {
+		field = DefaultVersionField
+	}
+
+	raw, ok := fields[field]
+	if !ok {
+		return 0, false, nil
+	}
+
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, false, DecodeError{EventName: eventName, Err: fmt.Errorf("failed to read payload version: %w", err)}
+	}
+
+	return version, true, nil
+}
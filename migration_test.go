@@ -0,0 +1,112 @@
+package rebound_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+// CustomerRegistered is the latest (version 3) of the event, where version 1
+// had "name" and version 2 split it into "firstName" and "lastName".
+type CustomerRegistered struct {
+	Version   int    `json:"version"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+}
+
+func registerCustomerMigrations(rb *rebound.Rebound) {
+	rb.RegisterMigration("customer.registered", 1, func(old []byte) ([]byte, error) {
+		var v1 struct {
+			Name string `json:"name"`
+		}
+
+		if err := json.Unmarshal(old, &v1); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(map[string]interface{}{
+			"version":   2,
+			"firstName": v1.Name,
+		})
+	})
+
+	rb.RegisterMigration("customer.registered", 2, func(old []byte) ([]byte, error) {
+		var v2 map[string]interface{}
+		if err := json.Unmarshal(old, &v2); err != nil {
+			return nil, err
+		}
+
+		v2["version"] = 3
+		v2["email"] = "unknown"
+		return json.Marshal(v2)
+	})
+}
+
+func TestRegisterMigration(t *testing.T) {
+	testCases := map[string]struct {
+		data string
+		want CustomerRegistered
+	}{
+		"v1": {
+			data: `{"version":1,"name":"John"}`,
+			want: CustomerRegistered{Version: 3, FirstName: "John", Email: "unknown"},
+		},
+		"v2": {
+			data: `{"version":2,"firstName":"John","lastName":"Doe"}`,
+			want: CustomerRegistered{Version: 3, FirstName: "John", LastName: "Doe", Email: "unknown"},
+		},
+		"latest": {
+			data: `{"version":3,"firstName":"John","lastName":"Doe","email":"john@example.com"}`,
+			want: CustomerRegistered{Version: 3, FirstName: "John", LastName: "Doe", Email: "john@example.com"},
+		},
+		"unversioned": {
+			data: `{"firstName":"John"}`,
+			want: CustomerRegistered{FirstName: "John"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := &rebound.Rebound{}
+			registerCustomerMigrations(rb)
+
+			var got CustomerRegistered
+			rb.ReactTo("customer.registered", func(event CustomerRegistered) error {
+				got = event
+				return nil
+			})
+
+			if err := rb.Dispatch("customer.registered", []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterMigration_versionField(t *testing.T) {
+	rb := rebound.New(rebound.WithVersionField("schemaVersion"))
+
+	migrateErr := errors.New("migrate error")
+	rb.RegisterMigration("customer.registered", 1, func(old []byte) ([]byte, error) {
+		return nil, migrateErr
+	})
+
+	rb.ReactTo("customer.registered", func(event CustomerRegistered) error { return nil })
+
+	err := rb.Dispatch("customer.registered", []byte(`{"schemaVersion":1}`))
+	if !errors.Is(err, migrateErr) {
+		t.Errorf("got %v, want %v", err, migrateErr)
+	}
+
+	err = rb.Dispatch("customer.registered", []byte(`{"version":1}`))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
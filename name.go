@@ -0,0 +1,21 @@
+package rebound
+
+import "strings"
+
+// EventName returns the event name for the action on the domain, such as
+// "order.completed" for EventName("order", "completed").
+func EventName(domain, action string) string {
+	return domain + "." + action
+}
+
+// SplitEventName splits the event name into its domain and action at the last
+// ".", so "order.item.added" yields "order.item" and "added". It reports false
+// if the name has no "." or either part is empty.
+func SplitEventName(name string) (domain, action string, ok bool) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+
+	return name[:i], name[i+1:], true
+}
@@ -0,0 +1,60 @@
+package rebound_test
+
+import (
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestEventName(t *testing.T) {
+	testCases := map[string]struct {
+		domain string
+		action string
+		want   string
+	}{
+		"simple":        {domain: "order", action: "completed", want: "order.completed"},
+		"dotted domain": {domain: "order.item", action: "added", want: "order.item.added"},
+		"empty action":  {domain: "order", want: "order."},
+		"empty domain":  {action: "completed", want: ".completed"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := rebound.EventName(tc.domain, tc.action); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitEventName(t *testing.T) {
+	testCases := map[string]struct {
+		name       string
+		wantDomain string
+		wantAction string
+		wantOK     bool
+	}{
+		"simple":         {name: "order.completed", wantDomain: "order", wantAction: "completed", wantOK: true},
+		"extra segments": {name: "order.item.added", wantDomain: "order.item", wantAction: "added", wantOK: true},
+		"no separator":   {name: "order"},
+		"empty":          {name: ""},
+		"empty domain":   {name: ".completed"},
+		"empty action":   {name: "order."},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			domain, action, ok := rebound.SplitEventName(tc.name)
+			if domain != tc.wantDomain || action != tc.wantAction || ok != tc.wantOK {
+				t.Errorf("got (%q, %q, %t), want (%q, %q, %t)", domain, action, ok, tc.wantDomain, tc.wantAction, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestSplitEventName_roundTrip(t *testing.T) {
+	domain, action, ok := rebound.SplitEventName(rebound.EventName("invoice.line", "issued"))
+	if !ok || domain != "invoice.line" || action != "issued" {
+		t.Errorf("got (%q, %q, %t), want (%q, %q, true)", domain, action, ok, "invoice.line", "issued")
+	}
+}
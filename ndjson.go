@@ -0,0 +1,48 @@
+package rebound
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DispatchNDJSON reads newline-delimited records from rd and dispatches each
+// of them. The parse function extracts the event name and data from a line.
+//
+// Blank lines are skipped. Dispatching continues when a line fails to parse
+// or dispatch; the returned errors are the failures annotated with their line
+// number, or nil when all lines are dispatched successfully. Reading stops on
+// the first read error, which is also returned.
+func (r *Rebound) DispatchNDJSON(rd io.Reader, parse func(line []byte) (name string, data []byte, err error)) []error {
+	var errs []error
+
+	br := bufio.NewReader(rd)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			errs = append(errs, fmt.Errorf("rebound: line %d: %w", lineNum, err))
+			return errs
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if dErr := r.dispatchLine(line, parse); dErr != nil {
+				errs = append(errs, fmt.Errorf("rebound: line %d: %w", lineNum, dErr))
+			}
+		}
+
+		if err != nil {
+			return errs
+		}
+	}
+}
+
+func (r *Rebound) dispatchLine(line []byte, parse func(line []byte) (name string, data []byte, err error)) error {
+	name, data, err := parse(line)
+	if err != nil {
+		return err
+	}
+
+	return r.Dispatch(name, data)
+}
@@ -0,0 +1,77 @@
+package rebound_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func parseTabSeparated(line []byte) (string, []byte, error) {
+	name, data, found := bytes.Cut(line, []byte("\t"))
+	if !found {
+		return "", nil, errors.New("missing tab separator")
+	}
+
+	return string(name), data, nil
+}
+
+func TestDispatchNDJSON(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var orderIDs []string
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		orderIDs = append(orderIDs, event.OrderID)
+		return nil
+	})
+
+	input := strings.Join([]string{
+		"order.completed\t{\"OrderID\":\"1\"}",
+		"malformed line",
+		"",
+		"order.completed\t{\"OrderID\":\"2\"}",
+		"order.unknown\t{}",
+		"order.completed\t{\"OrderID\":\"3\"}",
+	}, "\n")
+
+	errs := rb.DispatchNDJSON(strings.NewReader(input), parseTabSeparated)
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d errors, want %d (errors: %v)", got, want, errs)
+	}
+
+	if got, want := errs[0].Error(), "rebound: line 2: missing tab separator"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var noHandlerErr rebound.NoHandlerError
+	if !errors.As(errs[1], &noHandlerErr) {
+		t.Errorf("got %v, want NoHandlerError", errs[1])
+	}
+
+	if got, want := strings.Join(orderIDs, ","), "1,2,3"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDispatchNDJSON_allSucceed(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+
+	input := "order.completed\t{\"OrderID\":\"1\"}\norder.completed\t{\"OrderID\":\"2\"}\n"
+
+	errs := rb.DispatchNDJSON(strings.NewReader(input), parseTabSeparated)
+	if errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
@@ -0,0 +1,130 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// ReactToOnce registers an event handler for a given event name, called on
+// the next dispatch of the event only. The handler is unregistered before
+// being called, and concurrent dispatches call it once.
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToOnce(eventName string, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	h := newHandler(fn, 0)
+	h.once = &atomic.Bool{}
+	r.addHandler(eventName, h)
+}
+
+// WaitFor blocks until the next dispatch of the event, and returns the event
+// decoded like for a handler taking an interface{} event, into the concrete
+// types registered using RegisterType, or as is, such as into a
+// map[string]interface{}, if no type is registered. The handler waiting for
+// the event is unregistered once the event is dispatched or ctx is done.
+//
+// It returns an error if the event already has a handler.
+func (r *Rebound) WaitFor(ctx context.Context, eventName string) (interface{}, error) {
+	if eventName == "" {
+		return nil, ErrEmptyName
+	}
+
+	events := make(chan interface{}, 1)
+	done := make(chan struct{})
+	h := newHandler(func(event interface{}) error {
+		defer close(done)
+		events <- event
+		return nil
+	}, 0)
+	h.once = &atomic.Bool{}
+
+	r.mu.Lock()
+	if r.hasHandler(eventName) {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("rebound: event %q already has a handler", eventName)
+	}
+
+	r.addHandler(eventName, h)
+	r.mu.Unlock()
+
+	select {
+	case event := <-events:
+		return event, nil
+	case <-ctx.Done():
+	}
+
+	if !h.once.CompareAndSwap(false, true) {
+		// the event is being dispatched, and the handler fires right before
+		// being called, so done is closed once it returns
+		<-done
+		select {
+		case event := <-events:
+			return event, nil
+		default:
+			return nil, ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	r.removeHandler(eventName, h)
+	r.mu.Unlock()
+
+	return nil, ctx.Err()
+}
+
+// fired reports whether the handler is called once and already fired.
+func (h *handler) fired() bool {
+	return h.once != nil && h.once.Load()
+}
+
+// fire reports whether the handler should be called, unregistering it if it
+// is called once.
+func (r *Rebound) fire(eventName string, h *handler) bool {
+	if h.once == nil {
+		return true
+	}
+
+	if !h.once.CompareAndSwap(false, true) {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeHandler(eventName, h)
+	return true
+}
+
+// removeHandler unregisters the handler of the event. The caller must hold
+// the lock.
+func (r *Rebound) removeHandler(eventName string, h *handler) {
+	prev := r.reg.handlers[eventName]
+	hs := make([]*handler, 0, len(prev))
+	for _, ph := range prev {
+		if ph != h {
+			hs = append(hs, ph)
+		}
+	}
+
+	if len(hs) == 0 {
+		delete(r.reg.handlers, eventName)
+		return
+	}
+
+	r.reg.handlers[eventName] = hs
+}
@@ -0,0 +1,145 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestReactToOnce(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactToOnce("startup.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	if err := rb.Dispatch("startup.completed", []byte(`{"OrderID":"1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := rb.Dispatch("startup.completed", []byte(`{"OrderID":"2"}`))
+	if !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+
+	if len(got) != 1 || got[0] != "1" {
+		t.Errorf("got %v, want [1]", got)
+	}
+
+	// the event can be reacted to again once the handler is unregistered
+	rb.ReactToOnce("startup.completed", func(event OrderEvent) error {
+		return nil
+	})
+}
+
+func TestReactToOnce_concurrent(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var calls atomic.Int32
+	rb.ReactToOnce("startup.completed", func(event OrderEvent) error {
+		calls.Add(1)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rb.Dispatch("startup.completed", []byte(`{}`))
+		}()
+	}
+
+	wg.Wait()
+
+	if got, want := calls.Load(), int32(1); got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("startup.completed", InvoiceIssued{})
+
+	go func() {
+		for rb.Explain("startup.completed").Kind == rebound.MatchNone {
+			runtime.Gosched()
+		}
+
+		rb.Dispatch("startup.completed", []byte(`{"type":"startup.completed","InvoiceID":"inv-1"}`))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event, err := rb.WaitFor(ctx, "startup.completed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := event, (InvoiceIssued{InvoiceID: "inv-1"}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, want := rb.Explain("startup.completed").Kind, rebound.MatchNone; got != want {
+		t.Errorf("got match kind %v, want %v", got, want)
+	}
+}
+
+func TestWaitFor_untyped(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	go func() {
+		for rb.Explain("startup.completed").Kind == rebound.MatchNone {
+			runtime.Gosched()
+		}
+
+		rb.Dispatch("startup.completed", []byte(`{"Version":"1"}`))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	event, err := rb.WaitFor(ctx, "startup.completed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := event, (map[string]interface{}{"Version": "1"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWaitFor_canceled(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := rb.WaitFor(ctx, "startup.completed")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if got, want := rb.Explain("startup.completed").Kind, rebound.MatchNone; got != want {
+		t.Errorf("got match kind %v, want %v", got, want)
+	}
+}
+
+func TestWaitFor_existingHandler(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("startup.completed", func(event OrderEvent) error { return nil })
+
+	if _, err := rb.WaitFor(context.Background(), "startup.completed"); err == nil {
+		t.Error("expect error")
+	}
+}
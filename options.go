@@ -0,0 +1,469 @@
+package rebound
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Option configures a Rebound.
+type Option func(*Rebound)
+
+// WithPanicOnUnhandled makes Dispatch panic with the NoHandlerError message
+// instead of returning it when there is no handler for the event.
+//
+// This is meant for development, to fail loudly on unhandled events.
+// Default is false.
+func WithPanicOnUnhandled(enabled bool) Option {
+	return func(r *Rebound) {
+		r.panicOnUnhandled = enabled
+	}
+}
+
+// WithErrorHandler sets a function called whenever a dispatch results in an
+// error, including NoHandlerError and DecodeError. The error is still returned
+// to the caller.
+//
+// The data is the event data as dispatched, before any pre-decode step,
+// migration or data path extraction. It is nil when dispatching from a reader
+// that has not been read into memory.
+func WithErrorHandler(fn func(eventName string, data []byte, err error)) Option {
+	return func(r *Rebound) {
+		r.errorHandler = fn
+	}
+}
+
+// WithSynchronous sets whether the asynchronous dispatch, such as
+// DispatchAsync, runs inline in the caller's goroutine. Consume still runs in
+// its own goroutine, as its failures are received while consuming.
+//
+// This is primarily for testing, so handling is deterministic without
+// coordinating goroutines. Default is true.
+func WithSynchronous(enabled bool) Option {
+	return func(r *Rebound) {
+		r.async = !enabled
+	}
+}
+
+// WithMaxPayloadSize sets the maximum size in bytes of the event data.
+// Dispatching a larger event data returns PayloadTooLargeError without
+// decoding it. Zero or negative means no limit, which is the default.
+func WithMaxPayloadSize(n int) Option {
+	return func(r *Rebound) {
+		r.maxPayloadSize = n
+	}
+}
+
+// WithSeparator sets the separator of the event name segments used for the
+// pattern matching (see ReactToPattern). Default is DefaultSeparator.
+func WithSeparator(sep string) Option {
+	return func(r *Rebound) {
+		r.sep = sep
+	}
+}
+
+// WithVersionField sets the JSON field holding the payload version used by the
+// migrations (see RegisterMigration). Default is DefaultVersionField.
+func WithVersionField(field string) Option {
+	return func(r *Rebound) {
+		r.versionField = field
+	}
+}
+
+// WithDataPath sets the dotted path of the JSON sub-document holding the
+// event, such as "data" for {"data": {...}}. The migrations and the
+// discriminator of polymorphic events see the whole payload, and handlers
+// registered using ReactToFunc get it as is. Default is the root document.
+func WithDataPath(path string) Option {
+	return func(r *Rebound) {
+		r.dataPath = path
+	}
+}
+
+// WithMiddleware adds middlewares to the dispatch. The first middleware is the
+// outermost one, so it runs first.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(r *Rebound) {
+		r.middlewares = append(r.middlewares, mws...)
+	}
+}
+
+// WithDecodeCache enables caching up to size decoded events, keyed by the event
+// name and a hash of the data, so dispatching identical data again skips the
+// decoding. The least recently used events are evicted first. Each dispatch
+// gets its own copy of the cached event.
+//
+// This is meant for replay-heavy workloads such as tests. Events dispatched
+// from a reader are not cached.
+func WithDecodeCache(size int) Option {
+	return func(r *Rebound) {
+		if size <= 0 {
+			r.decodeCache = nil
+			return
+		}
+
+		r.decodeCache = newDecodeCache(size)
+	}
+}
+
+// WithUnhandledTracking enables tracking the event names dispatched without a
+// handler, available through UnhandledEvents.
+func WithUnhandledTracking() Option {
+	return func(r *Rebound) {
+		r.unhandled = &unhandledEvents{}
+	}
+}
+
+// WithAudit sets a function called with an AuditRecord after each dispatch,
+// whether it succeeds or fails.
+func WithAudit(fn func(rec AuditRecord)) Option {
+	return func(r *Rebound) {
+		r.auditFn = fn
+	}
+}
+
+// WithDiscriminatorField sets the JSON field holding the discriminator of the
+// polymorphic events (see RegisterType). Default is DefaultDiscriminatorField.
+func WithDiscriminatorField(field string) Option {
+	return func(r *Rebound) {
+		r.discriminatorField = field
+	}
+}
+
+// WithEventCopy enables passing each handler its own deep copy of the decoded
+// event, so a handler mutating the event affects neither the next handlers
+// nor the decode cache.
+func WithEventCopy(enabled bool) Option {
+	return func(r *Rebound) {
+		r.eventCopy = enabled
+	}
+}
+
+// WithOverflowPolicy sets the policy applied when a channel registered using
+// ReactToChannel is full. Default is OverflowDropNewest.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(r *Rebound) {
+		r.overflow = policy
+	}
+}
+
+// WithEventNameFromHeader sets the envelope header holding the event name,
+// used by DispatchEnvelope when the envelope name is empty.
+func WithEventNameFromHeader(headerKey string) Option {
+	return func(r *Rebound) {
+		r.eventNameHeader = headerKey
+	}
+}
+
+// WithTypeCheck enables checking at registration that the decoder can decode
+// the event type, when the decoder implements TypeChecker. A registration
+// with an incompatible event type panics, instead of failing at the first
+// dispatch. The Decoder should then be set before registering the handlers.
+func WithTypeCheck(enabled bool) Option {
+	return func(r *Rebound) {
+		r.typeCheck = enabled
+	}
+}
+
+// WithContentTypeDecoders sets the decoders used by DispatchEnvelope, by the
+// media type of the envelope Content-Type header, such as
+// "application/msgpack". The media types are case-insensitive.
+func WithContentTypeDecoders(decoders map[string]Decoder) Option {
+	return func(r *Rebound) {
+		r.contentTypeDecoders = make(map[string]Decoder, len(decoders))
+		for contentType, decoder := range decoders {
+			r.contentTypeDecoders[mediaType(contentType)] = decoder
+		}
+	}
+}
+
+// WithRecover sets the dispatch paths recovering from panics, returning a
+// PanicError instead. Default is RecoverNone, so a panic keeps its stack
+// trace, such as when testing. The PanicError holds no stack trace unless
+// enabled with WithPanicStack.
+//
+// Each handler of an event is recovered independently, so a panicking
+// handler does not prevent the remaining handlers from running, and its
+// PanicError is joined with the errors of the other handlers.
+func WithRecover(mode RecoverMode) Option {
+	return func(r *Rebound) {
+		r.recoverMode = mode
+	}
+}
+
+// WithSkipValidation sets whether the registration skips validating the
+// handlers with ValidateHandler, such as for generated handlers whose
+// signatures are known to be valid. An invalid handler is then not reported
+// at registration, and may panic at registration or dispatch instead.
+func WithSkipValidation(enabled bool) Option {
+	return func(r *Rebound) {
+		r.skipValidation = enabled
+	}
+}
+
+// WithPreDecode sets a function transforming the event data before it is
+// decoded, such as to decrypt it. Its output is decoded instead, and its
+// error aborts the dispatch with a DecodeError. Handlers registered using
+// ReactToFunc get the data as is.
+func WithPreDecode(fn func(eventName string, data []byte) ([]byte, error)) Option {
+	return func(r *Rebound) {
+		r.preDecode = fn
+	}
+}
+
+// WithPostHandle sets a function called with the decoded event once its
+// handlers all succeed, such as to acknowledge it. It is not called when the
+// dispatch fails, or when no handler is called. Handlers registered using
+// ReactToFunc have no decoded event, so the function is not called for them.
+func WithPostHandle(fn func(eventName string, event interface{})) Option {
+	return func(r *Rebound) {
+		r.postHandle = fn
+	}
+}
+
+// WithUseNumber sets whether the JSONDecoder decodes the numbers held by
+// interface values, such as in map[string]interface{} events, as json.Number
+// instead of float64, keeping the precision of large integers. Other decoders
+// are not affected.
+func WithUseNumber(enabled bool) Option {
+	return func(r *Rebound) {
+		r.useNumber = enabled
+	}
+}
+
+// WithEventTransform sets a function called with a pointer to each decoded
+// event before the handlers, such as to fill in defaults. The handlers get
+// the event as modified, and an error aborts the dispatch.
+func WithEventTransform(fn func(eventName string, event interface{}) error) Option {
+	return func(r *Rebound) {
+		r.transform = fn
+	}
+}
+
+// WithDispatchTree enables recording the dispatches as a tree, available
+// through DispatchTree, to debug the cascades of events. The tree grows with
+// every dispatch, so this is meant for debugging and testing.
+func WithDispatchTree() Option {
+	return func(r *Rebound) {
+		r.tree = &dispatchTree{}
+	}
+}
+
+// WithSizeObserver sets a function called after each dispatch with the size
+// of the event data in bytes, such as for capacity planning. When dispatching
+// from a reader, the size is the number of bytes actually read.
+func WithSizeObserver(fn func(eventName string, bytes int)) Option {
+	return func(r *Rebound) {
+		r.sizeObserver = fn
+	}
+}
+
+// WithAllowEmptyData sets whether an empty event data, such as nil, decodes
+// to the zero value of the event type and calls the handlers. Default is
+// false, so the decoder fails on the empty data.
+func WithAllowEmptyData(allow bool) Option {
+	return func(r *Rebound) {
+		r.allowEmptyData = allow
+	}
+}
+
+// WithDuplicatePolicy sets what happens when ReactTo registers a handler for
+// an event that already has a handler. Default is DuplicatePanic.
+func WithDuplicatePolicy(policy DuplicatePolicy) Option {
+	return func(r *Rebound) {
+		r.duplicate = policy
+	}
+}
+
+// WithErrorMapper sets a function mapping the error of each dispatch before
+// it is returned, such as to wrap it in a domain error. Returning nil
+// suppresses the error. The error handler set using WithErrorHandler gets the
+// mapped error.
+func WithErrorMapper(fn func(eventName string, err error) error) Option {
+	return func(r *Rebound) {
+		r.errorMapper = fn
+	}
+}
+
+// WithKeyOrdering enables handling the events of the same key in order, even
+// under concurrent dispatch, while the events of different keys are handled
+// concurrently. The keyFn returns the key of the event, given as an envelope
+// holding the event name, data and key, and the headers when dispatched by
+// DispatchEnvelope. The data is nil when dispatching from a reader. Events
+// with an empty key are not ordered.
+//
+// The dispatches of the same key are handled in call order, DispatchAsync
+// included. A handler dispatching an event of its own key must pass its
+// context to DispatchContext, or the dispatch waits for the handler forever.
+func WithKeyOrdering(keyFn func(env Envelope) string) Option {
+	return func(r *Rebound) {
+		r.keyOrder = &keyOrder{keyFn: keyFn}
+	}
+}
+
+// WithConcurrencyLimitFailFast sets whether the dispatch of an event whose
+// handler, registered using ReactToWithConcurrencyLimit, reached its
+// concurrency limit fails with ConcurrencyLimitError instead of waiting.
+// Default is false.
+func WithConcurrencyLimitFailFast(enabled bool) Option {
+	return func(r *Rebound) {
+		r.limitFailFast = enabled
+	}
+}
+
+// WithLatencyTracking enables tracking the handling latency of each event,
+// available as approximate percentiles through Latencies.
+func WithLatencyTracking() Option {
+	return func(r *Rebound) {
+		r.latencies = &latencies{}
+	}
+}
+
+// WithClock sets the clock telling the time and running the timers, such as
+// a fake clock for deterministic timing tests. It is used by the audit
+// records, the latency tracking and DispatchBatchDeadline. Default is the
+// real clock.
+func WithClock(clock Clock) Option {
+	return func(r *Rebound) {
+		r.clk = clock
+	}
+}
+
+// WithPauseBuffer sets the maximum number of events buffered while paused
+// (see Pause), to be dispatched on Resume. The events dispatched while the
+// buffer is full are rejected with a PausedError. Default is 0, rejecting all
+// the events while paused.
+func WithPauseBuffer(limit int) Option {
+	return func(r *Rebound) {
+		r.pauseBuffer = limit
+	}
+}
+
+// WithPatternSpecificity sets whether the event names matching multiple
+// patterns (see ReactToPattern and ReactToRemaining) are handled by the most
+// specific pattern, rather than the first registered one. Default is false.
+//
+// The specificity of a pattern is ranked by its number of literal segments,
+// that is, neither "*" nor ">", the more the more specific. Among patterns
+// with as many literal segments, a pattern without ">" is more specific than
+// a pattern with ">", as "*" matches a single segment. Patterns ranking
+// equally are ordered by registration, the first registered one winning. For
+// example, "order.*.added" beats "order.item.>", which beats "order.>", while
+// "order.*.added" and "*.item.added" tie.
+func WithPatternSpecificity(enabled bool) Option {
+	return func(r *Rebound) {
+		r.specificity = enabled
+	}
+}
+
+// WithContextLogger adds a middleware putting the logger built for each
+// dispatched event into the handler context, such as a logger with the event
+// name as an attribute, retrieved using LoggerFromContext. A nil logger is
+// not put.
+func WithContextLogger(build func(eventName string) *slog.Logger) Option {
+	return func(r *Rebound) {
+		r.middlewares = append(r.middlewares, contextLogger(build))
+	}
+}
+
+// WithSlog sets a logger logging each dispatch: its start and finish at the
+// debug level, or its error at the error level, with the event name, the
+// duration and the error as attributes. Default is nil, logging nothing.
+func WithSlog(logger *slog.Logger) Option {
+	return func(r *Rebound) {
+		r.slog = logger
+	}
+}
+
+// WithGlobalConcurrency limits the number of handlers running at a time
+// across all the events to n, such as for the asynchronous dispatch or
+// DispatchBatchConcurrent. A handler call beyond the limit waits for a
+// running one to finish, or the dispatch context to be done. A handler
+// dispatching nested events holds its slot meanwhile, so n must leave room
+// for the nested handlers. Default is 0, for no limit.
+func WithGlobalConcurrency(n int) Option {
+	return func(r *Rebound) {
+		r.globalSem = nil
+		if n > 0 {
+			r.globalSem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithDecodeFallback sets a function called with the original event data
+// when it fails to decode into the event type, instead of returning the
+// DecodeError, such as to quarantine the bad events. The error returned by fn
+// becomes the result of the dispatch. When dispatching from a reader, the data
+// is read into memory before decoding, to be available to fn.
+func WithDecodeFallback(fn func(eventName string, data []byte, decodeErr error) error) Option {
+	return func(r *Rebound) {
+		r.decodeFallback = fn
+	}
+}
+
+// WithEventTTL sets the time to live of the events dispatched by
+// DispatchEnvelope, so the events older than d are dropped with an
+// ExpiredEventError. The timestampFn returns the time of the event, such as
+// from a header, or the zero time if unknown, in which case the event does
+// not expire. The age of the event is measured by the Rebound clock (see
+// WithClock).
+func WithEventTTL(d time.Duration, timestampFn func(env Envelope) time.Time) Option {
+	return func(r *Rebound) {
+		r.ttl, r.timestampFn = d, timestampFn
+	}
+}
+
+// WithRequireContentType sets whether DispatchEnvelope requires a non-empty
+// Content-Type header, rejecting the envelopes without one with a
+// MissingContentTypeError, so no data is decoded by a guess. Default is
+// false.
+func WithRequireContentType(required bool) Option {
+	return func(r *Rebound) {
+		r.requireContentType = required
+	}
+}
+
+// WithPartialDecode sets whether an event partially decoded by
+// LenientJSONDecoder is still handled. The handlers get the event with the
+// fields that decoded, and the DecodeError wrapping the PartialDecodeError is
+// joined with the errors of the handlers. Default is false, failing the
+// dispatch with the DecodeError.
+func WithPartialDecode(enabled bool) Option {
+	return func(r *Rebound) {
+		r.partialDecode = enabled
+	}
+}
+
+// WithPanicStack sets whether a PanicError recovered with WithRecover captures
+// the stack trace of the panic in its Stack field. Default is false, avoiding
+// the cost of capturing the stack on every recovered panic.
+func WithPanicStack(enabled bool) Option {
+	return func(r *Rebound) {
+		r.panicStack = enabled
+	}
+}
+
+// WithDeadLetterBatch sets the dispatches failing with an error to be
+// collected as dead letters, with their event name, data and error, and
+// flushed in batches of size to flush. The remaining dead letters are flushed
+// on Shutdown. The error of a flush is joined with the error of the dispatch
+// filling the batch. The flushes may run concurrently, and may dispatch the
+// dead letters again. It panics if size is not positive.
+func WithDeadLetterBatch(size int, flush func(items []DeadLetter) error) Option {
+	if size <= 0 {
+		panic("rebound: dead-letter batch size must be positive")
+	}
+
+	return func(r *Rebound) {
+		r.deadLetters = &deadLetterBatch{size: size, flush: flush}
+	}
+}
+
+// WithCanonicalHash sets whether the event data is hashed in its canonical
+// JSON form (see CanonicalJSON), for the decode cache and the PayloadHash of
+// the audit records, so logically equal JSON data hashes the same regardless
+// of key order or whitespace. Default is false, hashing the data as is.
+func WithCanonicalHash(enabled bool) Option {
+	return func(r *Rebound) {
+		r.canonicalHash = enabled
+	}
+}
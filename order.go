@@ -0,0 +1,101 @@
+package rebound
+
+import (
+	"context"
+	"sync"
+)
+
+// keyOrder queues the dispatches by key (see WithKeyOrdering).
+type keyOrder struct {
+	keyFn func(env Envelope) string
+
+	mu    sync.Mutex
+	tails map[string]chan struct{}
+}
+
+// enter queues a turn for the key, after the last queued one.
+func (o *keyOrder) enter(key string) *keyTurn {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.tails == nil {
+		o.tails = make(map[string]chan struct{})
+	}
+
+	t := &keyTurn{o: o, key: key, prev: o.tails[key], mine: make(chan struct{})}
+	o.tails[key] = t.mine
+	return t
+}
+
+// keyTurn is the turn of a dispatch in the queue of its key. A turn starts
+// once the previous one is done.
+type keyTurn struct {
+	o    *keyOrder
+	key  string
+	prev chan struct{}
+	mine chan struct{}
+
+	started bool
+}
+
+// wait waits for the turn to start, or ctx to be done.
+func (t *keyTurn) wait(ctx context.Context) error {
+	if t.prev != nil {
+		select {
+		case <-t.prev:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	t.started = true
+	return nil
+}
+
+// done ends the turn, letting the next one start. A turn given up while
+// waiting still ends only after the previous one, so the order holds.
+func (t *keyTurn) done() {
+	if t.started || t.prev == nil {
+		t.release()
+		return
+	}
+
+	go func() {
+		<-t.prev
+		t.release()
+	}()
+}
+
+func (t *keyTurn) release() {
+	t.o.mu.Lock()
+	defer t.o.mu.Unlock()
+
+	close(t.mine)
+	if t.o.tails[t.key] == t.mine {
+		delete(t.o.tails, t.key)
+	}
+}
+
+// heldKey is the context key marking a key whose turn is held by the
+// dispatch, so the nested dispatches of the same key do not wait for it.
+type heldKey string
+
+// takeTurn queues a turn for the payload in the order of its key, unless the
+// payload already has one, its key is empty, or ctx already holds the turn of
+// its key. It returns ctx marked as holding the turn of the payload, if any.
+func (r *Rebound) takeTurn(ctx context.Context, eventName string, p *payload) context.Context {
+	if r.keyOrder == nil {
+		return ctx
+	}
+
+	if p.turn == nil {
+		key := r.keyOrder.keyFn(Envelope{Name: eventName, Headers: p.headers, Data: p.data, Key: p.key})
+		if key == "" || ctx.Value(heldKey(key)) != nil {
+			return ctx
+		}
+
+		p.turn = r.keyOrder.enter(key)
+	}
+
+	return context.WithValue(ctx, heldKey(p.turn.key), true)
+}
@@ -0,0 +1,140 @@
+package rebound_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+type KeyedEvent struct {
+	Key string
+	Seq int
+}
+
+func TestWithKeyOrdering(t *testing.T) {
+	rb := rebound.New(
+		rebound.WithSynchronous(false),
+		rebound.WithKeyOrdering(func(env rebound.Envelope) string {
+			var event KeyedEvent
+			if err := json.Unmarshal(env.Data, &event); err != nil {
+				return ""
+			}
+
+			return event.Key
+		}),
+	)
+
+	var (
+		mu       sync.Mutex
+		seqs     = make(map[string][]int)
+		inFlight = make(map[string]int)
+	)
+
+	bStarted := make(chan struct{})
+	var bOnce sync.Once
+	rb.ReactTo("order.updated", func(event KeyedEvent) error {
+		mu.Lock()
+		inFlight[event.Key]++
+		if n := inFlight[event.Key]; n > 1 {
+			t.Errorf("key %s: got %d handlers in flight, want 1", event.Key, n)
+		}
+		mu.Unlock()
+
+		switch event.Key {
+		case "a":
+			if event.Seq == 0 {
+				// key a does not block key b
+				select {
+				case <-bStarted:
+				case <-time.After(time.Second):
+					t.Error("expect key b to be handled concurrently")
+				}
+			}
+		case "b":
+			bOnce.Do(func() { close(bStarted) })
+		}
+
+		time.Sleep(time.Duration(event.Seq%3) * time.Millisecond)
+
+		mu.Lock()
+		inFlight[event.Key]--
+		seqs[event.Key] = append(seqs[event.Key], event.Seq)
+		mu.Unlock()
+		return nil
+	})
+
+	const n = 10
+	var results []<-chan error
+	for seq := 0; seq < n; seq++ {
+		for _, key := range []string{"a", "b"} {
+			data, err := json.Marshal(KeyedEvent{Key: key, Seq: seq})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results = append(results, rb.DispatchAsync("order.updated", data))
+		}
+	}
+
+	for _, errc := range results {
+		if err := <-errc; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := make([]int, n)
+	for i := range want {
+		want[i] = i
+	}
+
+	for _, key := range []string{"a", "b"} {
+		if got := seqs[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("key %s: got sequence %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestWithKeyOrdering_nested(t *testing.T) {
+	// all the events have the same key
+	rb := rebound.New(rebound.WithKeyOrdering(func(env rebound.Envelope) string {
+		return "order"
+	}))
+
+	var got []string
+	var gotKey []byte
+	rb.ReactTo("order.completed", func(ctx context.Context, key []byte, event OrderEvent) error {
+		if event.OrderID == "outer" {
+			gotKey = key
+		}
+
+		got = append(got, event.OrderID)
+		if event.OrderID == "outer" {
+			// the nested dispatch of the same key does not wait for this one
+			return rb.DispatchContext(ctx, "order.completed", []byte(`{"OrderID":"nested"}`))
+		}
+
+		return nil
+	})
+
+	err := rb.DispatchEnvelope(rebound.Envelope{
+		Name: "order.completed",
+		Key:  []byte("k1"),
+		Data: []byte(`{"OrderID":"outer"}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"outer", "nested"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if want := "k1"; string(gotKey) != want {
+		t.Errorf("got key %q, want %q", gotKey, want)
+	}
+}
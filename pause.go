@@ -0,0 +1,75 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+)
+
+// PausedError indicates that an event was dispatched while dispatching is
+// paused (see Pause), and was rejected.
+type PausedError struct {
+	EventName string
+}
+
+// Error returns the error message for PausedError.
+func (e PausedError) Error() string {
+	return fmt.Sprintf("rebound: dispatch of event %q is paused", e.EventName)
+}
+
+// pausedEvent is an event buffered while paused.
+type pausedEvent struct {
+	eventName string
+	p         payload
+}
+
+// Pause pauses dispatching until Resume. While paused, a dispatch returns a
+// PausedError, unless buffering is enabled (see WithPauseBuffer), in which
+// case the event is buffered and the dispatch returns nil. The dispatches
+// running when pausing are not waited for.
+func (r *Rebound) Pause() {
+	r.pauseMu.Lock()
+	defer r.pauseMu.Unlock()
+
+	r.paused = true
+}
+
+// Resume resumes dispatching, first dispatching the events buffered while
+// paused, in order. Their errors are reported to the error handler (see
+// WithErrorHandler).
+func (r *Rebound) Resume() {
+	r.pauseMu.Lock()
+	buffered := r.buffered
+	r.paused, r.buffered = false, nil
+	r.pauseMu.Unlock()
+
+	for _, ev := range buffered {
+		r.dispatch(context.Background(), ev.eventName, ev.p)
+	}
+}
+
+// hold buffers or rejects the event while paused, reporting whether it did.
+// The error is a PausedError when rejected.
+func (r *Rebound) hold(eventName string, p *payload) (bool, error) {
+	r.pauseMu.Lock()
+	defer r.pauseMu.Unlock()
+
+	if !r.paused {
+		return false, nil
+	}
+
+	if len(r.buffered) >= r.pauseBuffer {
+		return true, PausedError{EventName: eventName}
+	}
+
+	// the caller may reuse the data once the dispatch returns, so the
+	// buffered event keeps a copy
+	data, err := p.bytes(eventName)
+	if err != nil {
+		return true, err
+	}
+
+	cp := *p
+	cp.data, cp.turn = append([]byte(nil), data...), nil
+	r.buffered = append(r.buffered, pausedEvent{eventName: eventName, p: cp})
+	return true, nil
+}
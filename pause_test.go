@@ -0,0 +1,138 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestPause(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	rb.Pause()
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"1"}`))
+	var pausedErr rebound.PausedError
+	if !errors.As(err, &pausedErr) {
+		t.Fatalf("got error %v, want %T", err, pausedErr)
+	}
+
+	if pausedErr.EventName != "order.completed" {
+		t.Errorf("got event name %q, want %q", pausedErr.EventName, "order.completed")
+	}
+
+	rb.Resume()
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"2"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPause_buffer(t *testing.T) {
+	var handled []error
+	rb := rebound.New(
+		rebound.WithPauseBuffer(2),
+		rebound.WithErrorHandler(func(eventName string, data []byte, err error) {
+			handled = append(handled, err)
+		}),
+	)
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	rb.Pause()
+
+	data := []byte(`{"OrderID":"1"}`)
+	if err := rb.Dispatch("order.completed", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the buffered event does not share the data
+	copy(data, `{"OrderID":"x"}`)
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"2"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var pausedErr rebound.PausedError
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"3"}`)); !errors.As(err, &pausedErr) {
+		t.Errorf("got error %v, want %T", err, pausedErr)
+	}
+
+	if len(got) != 0 {
+		t.Fatalf("got %v handled while paused, want none", got)
+	}
+
+	rb.Resume()
+
+	if want := []string{"1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the rejected event is reported to the error handler
+	if len(handled) != 1 || !errors.As(handled[0], &pausedErr) {
+		t.Errorf("got errors handled %v, want the %T only", handled, pausedErr)
+	}
+}
+
+func TestPause_keyOrdering(t *testing.T) {
+	for name, limit := range map[string]int{"rejected": 0, "buffered": 1} {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(
+				rebound.WithPauseBuffer(limit),
+				rebound.WithKeyOrdering(func(env rebound.Envelope) string {
+					return "order"
+				}),
+			)
+
+			var got []string
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				got = append(got, event.OrderID)
+				return nil
+			})
+
+			rb.Pause()
+			<-rb.DispatchAsync("order.completed", []byte(`{"OrderID":"1"}`))
+			rb.Resume()
+
+			done := make(chan error, 1)
+			go func() {
+				done <- rb.Dispatch("order.completed", []byte(`{"OrderID":"2"}`))
+			}()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("dispatch blocked on the turn of the paused event")
+			}
+
+			want := []string{"2"}
+			if limit > 0 {
+				want = []string{"1", "2"}
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
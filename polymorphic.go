@@ -0,0 +1,154 @@
+package rebound
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DefaultDiscriminatorField is the default JSON field holding the
+// discriminator of a polymorphic event.
+const DefaultDiscriminatorField = "type"
+
+// RegisterType registers the concrete event type of the sample for the given
+// discriminator. The sample is a struct, or a pointer to a struct when its
+// methods have pointer receivers.
+//
+// A handler taking an interface event, such as
+//
+//	func(event PaymentMethod) error
+//
+// receives the event decoded into the concrete type registered for the
+// discriminator found in the JSON payload (see WithDiscriminatorField). The
+// concrete type must implement the interface.
+// It panics if the discriminator already has a type.
+func (r *Rebound) RegisterType(discriminator string, sample interface{}) {
+	if discriminator == "" {
+		panic("rebound: discriminator is empty")
+	}
+
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		panic("rebound: sample is nil")
+	}
+
+	if k := typ.Kind(); k != reflect.Struct && (k != reflect.Pointer || typ.Elem().Kind() != reflect.Struct) {
+		panic(fmt.Sprintf("rebound: sample should be a struct or a pointer to a struct (got: %v)", typ))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.reg.types[discriminator]; exists {
+		panic(fmt.Sprintf("rebound: discriminator %q already has a type", discriminator))
+	}
+
+	if r.reg.types == nil {
+		r.reg.types = make(map[string]reflect.Type)
+	}
+
+	r.reg.types[discriminator] = typ
+}
+
+// decodeVariant decodes the payload into the concrete type registered for its
+// discriminator, returned as a value of the eventType interface.
+func (r *Rebound) decodeVariant(eventType reflect.Type, p *payload) (reflect.Value, error) {
+	// the payload is read by decodeEvent
+	data := p.data
+
+	discriminator, err := r.readDiscriminator(data)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if r.dataPath != "" {
+		if data, err = extractPath(data, r.dataPath); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	r.mu.RLock()
+	typ, ok := r.reg.types[discriminator]
+	r.mu.RUnlock()
+
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("no type registered for discriminator %q", discriminator)
+	}
+
+	if !typ.Implements(eventType) {
+		return reflect.Value{}, fmt.Errorf("type %v of discriminator %q does not implement %v", typ, discriminator, eventType)
+	}
+
+	var concrete reflect.Value
+	if typ.Kind() == reflect.Pointer {
+		concrete = reflect.New(typ.Elem())
+		err = r.payloadDecoder(p).Decode(data, concrete.Interface())
+	} else {
+		ptr := reflect.New(typ)
+		err = r.payloadDecoder(p).Decode(data, ptr.Interface())
+		concrete = ptr.Elem()
+	}
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	event := reflect.New(eventType).Elem()
+	event.Set(concrete)
+	return event, nil
+}
+
+// hasTypes reports whether concrete types are registered using RegisterType.
+func (r *Rebound) hasTypes() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.reg.types) > 0
+}
+
+func (r *Rebound) readDiscriminator(data []byte) (string, error) {
+	field := r.discriminatorField
+	if field == "" {
+		field = DefaultDiscriminatorField
+	}
+
+	discriminator, err := readStringField(data, field)
+	if err != nil {
+		return "", fmt.Errorf("failed to read discriminator: %w", err)
+	}
+
+	return discriminator, nil
+}
+
+// readStringField reads the string value of a top-level field of the JSON
+// data.
+func readStringField(data []byte, field string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+
+	raw, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("missing field %q", field)
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// DispatchPolymorphic handles an event whose name is the string value of the
+// typeField of its JSON data, so a single stream can carry several event
+// types. The whole data is decoded for the handler of the event.
+func (r *Rebound) DispatchPolymorphic(typeField string, data []byte) error {
+	eventName, err := readStringField(data, typeField)
+	if err != nil {
+		return fmt.Errorf("rebound: failed to read event name: %w", err)
+	}
+
+	return r.Dispatch(eventName, data)
+}
@@ -0,0 +1,172 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type PaymentMethod interface {
+	Describe() string
+}
+
+type CardPayment struct {
+	Last4 string
+}
+
+func (p CardPayment) Describe() string {
+	return "card " + p.Last4
+}
+
+type BankPayment struct {
+	Account string
+}
+
+func (p *BankPayment) Describe() string {
+	return "bank " + p.Account
+}
+
+func TestRegisterType(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("card", CardPayment{})
+	rb.RegisterType("bank", &BankPayment{})
+
+	var got []string
+	rb.ReactTo("payment.received", func(event PaymentMethod) error {
+		got = append(got, event.Describe())
+		return nil
+	})
+
+	testCases := map[string]struct {
+		data string
+		want string
+	}{
+		"value type":   {data: `{"type":"card","Last4":"4242"}`, want: "card 4242"},
+		"pointer type": {data: `{"type":"bank","Account":"123"}`, want: "bank 123"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			if err := rb.Dispatch("payment.received", []byte(tc.data)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != 1 || got[0] != tc.want {
+				t.Errorf("got %v, want [%s]", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegisterType_discriminatorField(t *testing.T) {
+	rb := rebound.New(rebound.WithDiscriminatorField("kind"))
+	rb.RegisterType("card", CardPayment{})
+
+	var got PaymentMethod
+	rb.ReactTo("payment.received", func(event PaymentMethod) error {
+		got = event
+		return nil
+	})
+
+	if err := rb.Dispatch("payment.received", []byte(`{"kind":"card","Last4":"4242"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (CardPayment{Last4: "4242"}); got != want {
+		t.Errorf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestRegisterType_decodeErrors(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.RegisterType("card", CardPayment{})
+	rb.RegisterType("order", OrderEvent{})
+
+	rb.ReactTo("payment.received", func(event PaymentMethod) error { return nil })
+
+	testCases := map[string]string{
+		"unknown discriminator": `{"type":"cash"}`,
+		"missing discriminator": `{"Last4":"4242"}`,
+		"not implementing":      `{"type":"order"}`,
+		"malformed":             `{"type":`,
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := rb.Dispatch("payment.received", []byte(data))
+
+			var decodeErr rebound.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Errorf("got %v, want DecodeError", err)
+			}
+		})
+	}
+}
+
+func TestDispatchPolymorphic(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, "completed "+event.OrderID)
+		return nil
+	})
+
+	rb.ReactTo("order.shipped", func(event OrderShipped) error {
+		got = append(got, "shipped "+event.OrderID)
+		return nil
+	})
+
+	stream := []string{
+		`{"kind":"order.completed","OrderID":"1"}`,
+		`{"kind":"order.shipped","OrderID":"1"}`,
+		`{"OrderID":"2","kind":"order.completed"}`,
+	}
+
+	for _, data := range stream {
+		if err := rb.DispatchPolymorphic("kind", []byte(data)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"completed 1", "shipped 1", "completed 2"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDispatchPolymorphic_error(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	testCases := map[string]string{
+		"missing field":    `{"OrderID":"1"}`,
+		"non-string field": `{"kind":1,"OrderID":"1"}`,
+		"invalid JSON":     `{`,
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := rb.DispatchPolymorphic("kind", []byte(data)); err == nil {
+				t.Error("expect error")
+			}
+		})
+	}
+
+	err := rb.DispatchPolymorphic("kind", []byte(`{"kind":"order.canceled"}`))
+	if !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+}
@@ -0,0 +1,38 @@
+package rebound
+
+import "reflect"
+
+// Reactor is an event handler implemented by a type, such as by a plugin,
+// rather than by a function (see RegisterReactor).
+type Reactor interface {
+	// EventName returns the name of the event handled.
+	EventName() string
+
+	// Handle handles the event, of the type returned by EventType.
+	Handle(event interface{}) error
+
+	// EventType returns the type the event data is decoded into.
+	EventType() reflect.Type
+}
+
+// RegisterReactor registers the reactor as the event handler of its event
+// name. The event data is decoded into its event type, then passed to Handle.
+// It panics if the event already has a handler, like ReactTo.
+func (r *Rebound) RegisterReactor(rc Reactor) {
+	if rc == nil {
+		panic("rebound: rc is nil")
+	}
+
+	eventType := rc.EventType()
+	if eventType == nil {
+		panic("rebound: reactor event type is nil")
+	}
+
+	fnType := reflect.FuncOf([]reflect.Type{eventType}, []reflect.Type{errorType}, false)
+	fn := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		err := rc.Handle(args[0].Interface())
+		return []reflect.Value{reflect.ValueOf(&err).Elem()}
+	})
+
+	r.ReactTo(rc.EventName(), fn.Interface())
+}
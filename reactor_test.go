@@ -0,0 +1,72 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type orderReactor struct {
+	err    error
+	events []OrderEvent
+}
+
+func (rc *orderReactor) EventName() string {
+	return "order.completed"
+}
+
+func (rc *orderReactor) Handle(event interface{}) error {
+	rc.events = append(rc.events, event.(OrderEvent))
+	return rc.err
+}
+
+func (rc *orderReactor) EventType() reflect.Type {
+	return reflect.TypeOf(OrderEvent{})
+}
+
+func TestRegisterReactor(t *testing.T) {
+	handleErr := errors.New("handle failed")
+	testCases := map[string]struct {
+		err error
+	}{
+		"success": {},
+		"error":   {err: handleErr},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rc := &orderReactor{err: tc.err}
+			rb := rebound.New()
+			rb.RegisterReactor(rc)
+
+			err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+			if !errors.Is(err, tc.err) {
+				t.Errorf("got error %v, want %v", err, tc.err)
+			}
+
+			if tc.err != nil && !errors.Is(err, rebound.ErrHandler) {
+				t.Errorf("got error %v, want %v", err, rebound.ErrHandler)
+			}
+
+			want := []OrderEvent{{OrderID: "123"}}
+			if !reflect.DeepEqual(rc.events, want) {
+				t.Errorf("got events %v, want %v", rc.events, want)
+			}
+		})
+	}
+}
+
+func TestRegisterReactor_duplicate(t *testing.T) {
+	rb := rebound.New()
+	rb.RegisterReactor(&orderReactor{})
+
+	defer func() {
+		if v := recover(); v == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb.RegisterReactor(&orderReactor{})
+}
@@ -3,9 +3,19 @@
 package rebound
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // EventHandler is a function type that handles an event.
@@ -16,6 +26,36 @@ import (
 //
 //	 where the Event is the event type (struct) that will be handled.
 //
+// The Event may also be an interface, for polymorphic events decoded into the
+// concrete types registered using RegisterType, or a map such as
+// map[string]interface{} for schemaless events. An interface{} Event is
+// decoded as is by the decoder, such as into a map[string]interface{}, if no
+// type is registered.
+//
+// The function may take a context.Context as the first input parameter,
+// which receives the context given to DispatchContext, and a []byte key
+// right before the event, which receives the key given to DispatchKeyed:
+//
+//	func(ctx context.Context, event Event) error
+//	func(key []byte, event Event) error
+//	func(ctx context.Context, key []byte, event Event) error
+//
+// The function may also take a []byte right after the event, which receives
+// the original event data, before any decoding step, such as to verify its
+// signature:
+//
+//	func(event Event, raw []byte) error
+//
+// The function may have additional output parameters, as long as exactly one
+// of them is an error, for example:
+//
+//	func(event Event) (error, ResultCode)
+//
+// The function may be a method value bound to its receiver, such as
+// svc.HandleOrderCompleted, in which case the receiver is not an input
+// parameter. A method expression, such as (*Service).HandleOrderCompleted,
+// takes the receiver as its first input parameter and is not a valid handler.
+//
 // Example:
 //
 //	eventually.HandleEvent(func(event OrderCompleted) error {
@@ -24,7 +64,24 @@ import (
 //	})
 type EventHandler any
 
+// Sentinel errors classifying the dispatch errors, to be checked using
+// errors.Is. The errors returned by the dispatch carry the details, such as
+// NoHandlerError, DecodeError or HandlerError.
+var (
+	ErrEmptyName = errors.New("rebound: event name is empty")
+	ErrNoHandler = errors.New("rebound: no handler")
+	ErrDecode    = errors.New("rebound: failed to decode")
+	ErrHandler   = errors.New("rebound: handler failed")
+)
+
 // NoHandlerError indicates that no handler was found for the given event.
+//
+// Only the dispatch finding no handler treats the event as unhandled. A
+// NoHandlerError returned by a handler, such as from a nested dispatch, is a
+// handler error like any other: it is wrapped in a HandlerError, and the
+// dispatch neither tracks the event as unhandled nor panics (see
+// WithPanicOnUnhandled). As errors.Is also matches the wrapped error against
+// ErrNoHandler, check ErrHandler first to tell the two apart.
 type NoHandlerError struct {
 	EventName string
 }
@@ -34,100 +91,1249 @@ func (e NoHandlerError) Error() string {
 	return fmt.Sprintf("rebound: no handler for event %q", e.EventName)
 }
 
+// Is reports whether target is ErrNoHandler.
+func (e NoHandlerError) Is(target error) bool {
+	return target == ErrNoHandler
+}
+
+// DecodeError indicates that the event data failed to decode.
+type DecodeError struct {
+	EventName string
+	Err       error
+}
+
+// Error returns the error message for DecodeError.
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("rebound: failed to unmarshal event %q data: %v", e.EventName, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrDecode.
+func (e DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+// HandlerError indicates that a handler of the event returned an error.
+type HandlerError struct {
+	EventName string
+	Err       error
+}
+
+// Error returns the error message for HandlerError.
+func (e HandlerError) Error() string {
+	return fmt.Sprintf("rebound: handler of event %q failed: %v", e.EventName, e.Err)
+}
+
+// Unwrap returns the error returned by the handler.
+func (e HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrHandler.
+func (e HandlerError) Is(target error) bool {
+	return target == ErrHandler
+}
+
+// PayloadTooLargeError indicates that the event data exceeds the maximum
+// payload size. When dispatching from a reader, Size is the number of bytes
+// read until the limit was exceeded.
+type PayloadTooLargeError struct {
+	EventName string
+	Size      int
+	Limit     int
+}
+
+// Error returns the error message for PayloadTooLargeError.
+func (e PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("rebound: event %q payload size %d exceeds the limit %d", e.EventName, e.Size, e.Limit)
+}
+
 // Rebound manages event handlers and dispatching events.
+//
+// The zero value is ready to use. Use New to create a Rebound with options.
+// Registering handlers and dispatching events are safe for concurrent use,
+// while Decoder should be set before use.
 type Rebound struct {
-	handlers map[string]EventHandler
-	Decoder  Decoder
+	mu  sync.RWMutex
+	reg registry
+
+	decodeCache *decodeCache
+	unhandled   *unhandledEvents
+	Decoder     Decoder
+
+	contentTypeDecoders map[string]Decoder
+
+	panicOnUnhandled   bool
+	errorHandler       func(eventName string, data []byte, err error)
+	async              bool
+	maxPayloadSize     int
+	sep                string
+	versionField       string
+	discriminatorField string
+	middlewares        []Middleware
+	auditFn            func(rec AuditRecord)
+	eventCopy          bool
+	overflow           OverflowPolicy
+	dataPath           string
+	eventNameHeader    string
+	typeCheck          bool
+	recoverMode        RecoverMode
+	skipValidation     bool
+	preDecode          func(eventName string, data []byte) ([]byte, error)
+	postHandle         func(eventName string, event interface{})
+	useNumber          bool
+	transform          func(eventName string, event interface{}) error
+	tree               *dispatchTree
+	sizeObserver       func(eventName string, bytes int)
+	allowEmptyData     bool
+	taps               []func(eventName string, event interface{})
+	duplicate          DuplicatePolicy
+	dupErrs            []error
+	errorMapper        func(eventName string, err error) error
+	keyOrder           *keyOrder
+	limitFailFast      bool
+	latencies          *latencies
+	clk                Clock
+	specificity        bool
+	slog               *slog.Logger
+	globalSem          chan struct{}
+	decodeFallback     func(eventName string, data []byte, decodeErr error) error
+	ttl                time.Duration
+	timestampFn        func(env Envelope) time.Time
+	requireContentType bool
+	partialDecode      bool
+	panicStack         bool
+	deadLetters        *deadLetterBatch
+	canonicalHash      bool
+	decoderScoped      bool
+	dropped            atomic.Uint64
+
+	pauseMu     sync.Mutex
+	paused      bool
+	pauseBuffer int
+	buffered    []pausedEvent
+}
+
+// New creates a new Rebound configured with the given options.
+func New(opts ...Option) *Rebound {
+	r := &Rebound{}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// handler is a registered EventHandler along with its reflection metadata.
+type handler struct {
+	fn        reflect.Value
+	eventType reflect.Type
+	in        handlerInputs
+	errIndex  int
+	priority  int
+	cond      func(event interface{}) bool
+	each      bool
+	once      *atomic.Bool
+	desc      string
+	sem       chan struct{}
+
+	// ready is closed once the handler may be called, when swapped in
+	ready <-chan struct{}
+
+	// inflight counts the dispatches that looked the handler up and are not
+	// done yet
+	inflight sync.WaitGroup
+}
+
+func newHandler(fn EventHandler, priority int) *handler {
+	fnType := reflect.TypeOf(fn)
+	in := parseInputs(fnType)
+	return &handler{
+		fn:        reflect.ValueOf(fn),
+		eventType: fnType.In(in.event),
+		in:        in,
+		errIndex:  errorOutIndex(fnType),
+		priority:  priority,
+	}
+}
+
+// accepts reports whether the handler should be called for the event.
+func (h *handler) accepts(event reflect.Value) bool {
+	return h.cond == nil || h.cond(event.Interface())
+}
+
+func (h *handler) call(ctx context.Context, p *payload, event reflect.Value) error {
+	args := make([]reflect.Value, 0, h.in.event+2)
+	if h.in.ctx {
+		args = append(args, reflect.ValueOf(&ctx).Elem())
+	}
+
+	if h.in.key {
+		args = append(args, reflect.ValueOf(p.key))
+	}
+
+	args = append(args, event)
+	if h.in.raw {
+		args = append(args, reflect.ValueOf(p.raw))
+	}
+
+	retVals := h.fn.Call(args)
+	errVal := retVals[h.errIndex]
+	if !errVal.IsNil() {
+		return errVal.Interface().(error)
+	}
+
+	return nil
 }
 
 // ReactTo registers an event handler for a given event name.
+// It panics if the event already has a handler, unless a different duplicate
+// policy is set using WithDuplicatePolicy.
 func (r *Rebound) ReactTo(eventName string, fn EventHandler) {
 	if eventName == "" {
 		panic("rebound: event name is empty")
 	}
 
-	err := ValidateHandler(fn)
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) && !r.resolveDuplicate(eventName) {
+		return
+	}
+
+	r.addHandler(eventName, newHandler(fn, 0))
+}
+
+// ReactToIf registers an event handler for a given event name that is called
+// only when cond returns true for the decoded event. Otherwise the event is
+// skipped and the dispatch returns nil.
+// It panics if the event already has a handler.
+func (r *Rebound) ReactToIf(eventName string, cond func(event interface{}) bool, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	if cond == nil {
+		panic("rebound: cond is nil")
+	}
+
+	err := r.validateHandler(fn)
 	if err != nil {
 		panic(err)
 	}
 
-	if r.handlers == nil {
-		r.handlers = make(map[string]EventHandler)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	h := newHandler(fn, 0)
+	h.cond = cond
+	r.addHandler(eventName, h)
+}
+
+// ReactToMany registers an event handler for each of the given event names.
+// It panics if any of the events already has a handler, in which case none of
+// them is registered.
+func (r *Rebound) ReactToMany(eventNames []string, fn EventHandler) {
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool, len(eventNames))
+	for _, eventName := range eventNames {
+		if eventName == "" {
+			panic("rebound: event name is empty")
+		}
+
+		if seen[eventName] || r.hasHandler(eventName) {
+			panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+		}
+
+		seen[eventName] = true
+	}
+
+	for _, eventName := range eventNames {
+		r.addHandler(eventName, newHandler(fn, 0))
+	}
+}
+
+// ReactToFunc registers a raw data handler for a given event name.
+// It panics if the event already has a handler.
+//
+// The fn receives the undecoded event data, so it does its own decoding and
+// no reflection is involved on dispatch. Use it for hot events where the
+// dispatch overhead matters.
+func (r *Rebound) ReactToFunc(eventName string, fn func(data []byte) error) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasHandler(eventName) {
+		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
+	}
+
+	if r.reg.funcs == nil {
+		r.reg.funcs = make(map[string]func(data []byte) error)
 	}
 
-	_, exists := r.handlers[eventName]
+	r.reg.funcs[eventName] = fn
+}
+
+// hasHandler reports whether the event has a handler. It requires r.mu to be
+// held.
+func (r *Rebound) hasHandler(eventName string) bool {
+	_, exists := r.reg.handlers[eventName]
 	if exists {
+		return true
+	}
+
+	_, exists = r.reg.funcs[eventName]
+	return exists
+}
+
+// ReactToWithPriority registers an additional event handler for a given event
+// name with the given priority.
+//
+// An event may have multiple handlers registered using ReactToWithPriority,
+// all of them handling the same event type. On dispatch, the event data is
+// decoded once and the handlers are called in ascending order of priority;
+// handlers with equal priority are called in registration order. A handler
+// registered using ReactTo has priority 0. All handlers are called even if
+// some of them fail, and the errors are joined.
+func (r *Rebound) ReactToWithPriority(eventName string, priority int, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	h := newHandler(fn, priority)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.reg.funcs[eventName]; exists {
 		panic(fmt.Sprintf("rebound: event %q already has a handler", eventName))
 	}
 
-	r.handlers[eventName] = fn
+	if hs := r.reg.handlers[eventName]; len(hs) > 0 && hs[0].eventType != h.eventType {
+		panic(fmt.Sprintf("rebound: event %q handler should handle %v (got: %v)", eventName, hs[0].eventType, h.eventType))
+	}
+
+	r.addHandler(eventName, h)
+}
+
+// addHandler adds the handler to the event. It requires r.mu to be held.
+//
+// The handlers slice is replaced rather than modified in place, since
+// dispatches and snapshots may hold the previous one.
+func (r *Rebound) addHandler(eventName string, h *handler) {
+	r.checkEventType(eventName, h.eventType)
+
+	if r.reg.handlers == nil {
+		r.reg.handlers = make(map[string][]*handler)
+	}
+
+	prev := r.reg.handlers[eventName]
+	hs := make([]*handler, 0, len(prev)+1)
+	hs = append(append(hs, prev...), h)
+	sort.SliceStable(hs, func(i, j int) bool {
+		return hs[i].priority < hs[j].priority
+	})
+
+	r.reg.handlers[eventName] = hs
 }
 
+// Dispatcher dispatches events by their name and associated data. It is
+// implemented by Rebound, and by the dispatchers generated by reboundgen.
+type Dispatcher interface {
+	Dispatch(eventName string, data []byte) error
+}
+
+var _ Dispatcher = (*Rebound)(nil)
+
 // Dispatch handles an event by its name and associated data.
 func (r *Rebound) Dispatch(eventName string, data []byte) error {
+	return r.DispatchContext(context.Background(), eventName, data)
+}
+
+// DispatchContext handles an event by its name and associated data, passing
+// ctx to the handlers that take a context.Context.
+//
+// When the event has multiple handlers, ctx is checked before calling each of
+// them. Once ctx is done, the remaining handlers are not called and the
+// errors so far are joined with ctx.Err().
+func (r *Rebound) DispatchContext(ctx context.Context, eventName string, data []byte) error {
+	_, err := r.dispatch(ctx, eventName, payload{data: data})
+	return err
+}
+
+// TryDispatch handles an event by its name and associated data, and reports
+// whether a handler ran. A handler that ran may still return an error, while
+// a skipped event, such as one rejected by the ReactToIf condition, results
+// in ran being false with a nil error.
+func (r *Rebound) TryDispatch(ctx context.Context, eventName string, data []byte) (ran bool, err error) {
+	return r.dispatch(ctx, eventName, payload{data: data})
+}
+
+// DispatchReader handles an event by its name and the data read from rd.
+//
+// If the decoder implements ReaderDecoder, the data is decoded directly from
+// rd. Otherwise, rd is read until EOF and the data is passed to the decoder.
+func (r *Rebound) DispatchReader(eventName string, rd io.Reader) error {
+	_, err := r.dispatch(context.Background(), eventName, payload{rd: rd})
+	return err
+}
+
+// DispatchKeyed handles an event by its name, key and value, such as a Kafka
+// message. The value is decoded as the event data, while the key is passed
+// as is to the handlers that take a key. See EventHandler.
+func (r *Rebound) DispatchKeyed(eventName string, key, value []byte) error {
+	_, err := r.dispatch(context.Background(), eventName, payload{data: value, key: key})
+	return err
+}
+
+// DispatchAsync handles an event by its name and associated data in a new
+// goroutine. The returned channel receives the result and is then closed.
+// The data must not be modified until the result is received.
+//
+// In synchronous mode (see WithSynchronous) the event is handled before
+// DispatchAsync returns.
+func (r *Rebound) DispatchAsync(eventName string, data []byte) <-chan error {
+	errc := make(chan error, 1)
+
+	p := payload{data: data, async: true}
+	// the turn is taken before the goroutine starts, so the async dispatches
+	// of the same key are handled in call order
+	r.takeTurn(context.Background(), eventName, &p)
+
+	dispatch := func() error {
+		_, err := r.dispatch(context.Background(), eventName, p)
+		return err
+	}
+
+	if !r.async {
+		errc <- dispatch()
+		close(errc)
+		return errc
+	}
+
+	go func() {
+		defer close(errc)
+		errc <- dispatch()
+	}()
+
+	return errc
+}
+
+// payload is the event data, either in memory or to be read from a reader.
+type payload struct {
+	data []byte
+	rd   io.Reader
+	key  []byte
+	dec  Decoder
+
+	// raw is the original data, for the handlers taking it
+	raw []byte
+
+	// orig is the data as dispatched, kept for the error handler, the audit
+	// and the size observer while the decode steps transform data (see
+	// WithPreDecode, RegisterMigration and WithDataPath)
+	orig []byte
+
+	// headers are the envelope headers, when dispatched by DispatchEnvelope
+	headers map[string]string
+
+	// turn is the turn of the payload in its key order (see WithKeyOrdering)
+	turn *keyTurn
+
+	// async reports whether the payload is dispatched by DispatchAsync
+	async bool
+
+	// dryRun reports whether the payload is dispatched by DryRun
+	dryRun bool
+
+	// prepared reports whether the pre-decode step and the migrations already
+	// ran on data (see prepareData)
+	prepared bool
+
+	limit int
+	lr    *io.LimitedReader
+}
+
+// setLimit sets the maximum size of the data. The reader is limited to one
+// byte more than the limit, so exceeding it can be detected.
+func (p *payload) setLimit(n int) {
+	p.limit = n
+	if p.rd != nil {
+		p.lr = &io.LimitedReader{R: p.rd, N: int64(n) + 1}
+		p.rd = p.lr
+	}
+}
+
+// checkLimit returns PayloadTooLargeError if the data seen so far exceeds the
+// limit.
+func (p *payload) checkLimit(eventName string) error {
+	if p.limit <= 0 {
+		return nil
+	}
+
+	size := len(p.data)
+	if p.lr != nil && p.lr.N == 0 {
+		size = p.limit + 1
+	}
+
+	if size > p.limit {
+		return PayloadTooLargeError{EventName: eventName, Size: size, Limit: p.limit}
+	}
+
+	return nil
+}
+
+// keepRaw keeps the original data before it is decoded, reading it if
+// needed.
+func (p *payload) keepRaw(eventName string) error {
+	data, err := p.bytes(eventName)
+	if err != nil {
+		return err
+	}
+
+	p.raw = data
+	return nil
+}
+
+// takeRaw reports whether any of the handlers takes the raw data.
+func takeRaw(hs []*handler) bool {
+	for _, h := range hs {
+		if h.in.raw {
+			return true
+		}
+	}
+
+	return false
+}
+
+// empty reports whether the event data is empty. When reading from a reader,
+// it peeks at the reader without consuming the data.
+func (p *payload) empty() bool {
+	if p.rd == nil {
+		return len(p.data) == 0
+	}
+
+	br := bufio.NewReader(p.rd)
+	p.rd = br
+	_, err := br.Peek(1)
+	return err == io.EOF
+}
+
+// bytes returns the event data, reading it until EOF if needed. A read
+// failure is a DecodeError of the event, like when decoding from the reader.
+func (p *payload) bytes(eventName string) ([]byte, error) {
+	if p.rd != nil {
+		data, err := io.ReadAll(p.rd)
+		if err != nil {
+			return nil, DecodeError{EventName: eventName, Err: fmt.Errorf("failed to read event data: %w", err)}
+		}
+
+		p.data, p.orig, p.rd = data, data, nil
+	}
+
+	return p.data, nil
+}
+
+func (r *Rebound) dispatch(ctx context.Context, eventName string, p payload) (ran bool, err error) {
+	p.orig = p.data
+
+	if held, err := r.hold(eventName, &p); held {
+		// the turn taken by DispatchAsync is given up, as the buffered event
+		// takes a new one on Resume
+		if p.turn != nil {
+			p.turn.done()
+		}
+
+		return false, r.handleError(eventName, p.orig, err)
+	}
+
+	ctx = r.takeTurn(ctx, eventName, &p)
+	if p.turn != nil {
+		defer p.turn.done()
+		if err := p.turn.wait(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	if r.slog != nil {
+		finish := r.logDispatch(ctx, eventName)
+		defer func() {
+			finish(err)
+		}()
+	}
+
+	if r.auditFn != nil {
+		start := r.clock().Now()
+		defer func() {
+			r.audit(eventName, start, p.orig, err)
+		}()
+	}
+
+	if r.sizeObserver != nil {
+		defer r.observeSize(eventName, &p)()
+	}
+
+	if r.tree != nil {
+		var n *treeNode
+		ctx, n = r.tree.enter(ctx, eventName)
+		defer func() {
+			r.tree.leave(n, err)
+		}()
+	}
+
+	if r.latencies != nil {
+		start := r.clock().Now()
+		defer func() {
+			if ran {
+				r.latencies.add(eventName, r.clock().Now().Sub(start))
+			}
+		}()
+	}
+
+	ran, err = r.routeRecover(ctx, eventName, &p)
+	return ran, r.handleError(eventName, p.orig, err)
+}
+
+// handleError maps the error of a dispatch and reports it to the error
+// handler and the dead-letter batch, returning the mapped error.
+func (r *Rebound) handleError(eventName string, data []byte, err error) error {
+	if err != nil && r.errorMapper != nil {
+		err = r.errorMapper(eventName, err)
+	}
+
+	if err != nil && r.errorHandler != nil {
+		r.errorHandler(eventName, data, err)
+	}
+
+	if err != nil {
+		if flushErr := r.deadLetter(eventName, data, err); flushErr != nil {
+			err = errors.Join(err, flushErr)
+		}
+	}
+
+	return err
+}
+
+func (r *Rebound) route(ctx context.Context, eventName string, p *payload) (ran bool, err error) {
 	if eventName == "" {
-		return fmt.Errorf("rebound: event name is empty")
+		return false, ErrEmptyName
 	}
 
-	fn := r.handlers[eventName]
-	if fn == nil {
-		return NoHandlerError{EventName: eventName}
+	if err := ctx.Err(); err != nil {
+		return false, err
 	}
 
-	fnType := reflect.TypeOf(fn)
-	event := reflect.New(fnType.In(0))
+	if r.maxPayloadSize > 0 {
+		p.setLimit(r.maxPayloadSize)
+		if err := p.checkLimit(eventName); err != nil {
+			return false, err
+		}
+	}
+
+	fn, hs, ms := r.lookup(eventName)
+	defer releaseHandlers(hs)
+	if fn != nil {
+		data, err := p.bytes(eventName)
+		if err != nil {
+			return false, err
+		}
+
+		if err := p.checkLimit(eventName); err != nil {
+			return false, err
+		}
+
+		if p.dryRun {
+			return false, nil
+		}
+
+		release, err := r.acquireGlobal(ctx)
+		if err != nil {
+			return false, err
+		}
+		defer release()
+
+		if err := fn(data); err != nil {
+			return true, HandlerError{EventName: eventName, Err: err}
+		}
+
+		return true, nil
+	}
+
+	if len(hs) == 0 {
+		if r.unhandled != nil && !p.dryRun {
+			r.unhandled.add(eventName)
+		}
+
+		var err error = NoHandlerError{EventName: eventName}
+		if r.isDeclared(eventName) {
+			err = PendingHandlerError{EventName: eventName}
+		}
+
+		if r.panicOnUnhandled && !p.dryRun {
+			panic(err.Error())
+		}
+
+		return false, err
+	}
+
+	if r.decodeFallback != nil || takeRaw(hs) {
+		if err := p.keepRaw(eventName); err != nil {
+			return false, err
+		}
+	}
+
+	var events []reflect.Value
+	if hs[0].each {
+		events, err = r.decodeEach(eventName, hs[0].eventType, ms, p)
+	} else {
+		var event reflect.Value
+		event, err = r.decodeEvent(eventName, hs[0].eventType, ms, p)
+		events = []reflect.Value{event}
+	}
+
+	// a partially decoded event comes with its error (see WithPartialDecode)
+	var partialErr error
+	if err != nil && len(events) == 1 && events[0].IsValid() {
+		partialErr, err = err, nil
+	}
+
+	var decodeErr DecodeError
+	if err != nil && r.decodeFallback != nil && !p.dryRun && errors.As(err, &decodeErr) {
+		return true, r.decodeFallback(eventName, p.raw, err)
+	}
 
-	err := r.decode(data, event.Interface())
 	if err != nil {
-		return fmt.Errorf("rebound: failed to unmarshal event data: %w", err)
+		return false, err
 	}
 
-	fnValue := reflect.ValueOf(fn)
+	if r.transform != nil {
+		if err := r.transformEvents(eventName, events); err != nil {
+			return false, err
+		}
+	}
 
-	retVals := fnValue.Call([]reflect.Value{event.Elem()})
-	if !retVals[0].IsNil() {
-		return retVals[0].Interface().(error)
+	if p.dryRun {
+		return false, partialErr
 	}
 
+	r.tapEvents(eventName, events)
+	returnEvents(ctx, hs[0].each, events)
+
+	var (
+		errs     []error
+		handled  []reflect.Value
+		recovers = r.recovers(p)
+	)
+
+	if partialErr != nil {
+		errs = append(errs, partialErr)
+	}
+
+	for _, event := range events {
+		var eventRan bool
+		for _, h := range hs {
+			if err := ctx.Err(); err != nil {
+				return ran, joinErrors(append(errs, err))
+			}
+
+			ev := event
+			if r.eventCopy {
+				ev = deepCopy(event)
+			}
+
+			if !h.accepts(ev) || h.fired() {
+				continue
+			}
+
+			called, err := r.callLimited(ctx, eventName, h, p, ev, recovers)
+			if !called && err == nil {
+				// a concurrent dispatch called the once handler first
+				continue
+			}
+
+			ran, eventRan = true, true
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if eventRan {
+			handled = append(handled, event)
+		}
+	}
+
+	if len(errs) > 0 {
+		return ran, joinErrors(errs)
+	}
+
+	if r.postHandle != nil {
+		for _, event := range handled {
+			r.postHandle(eventName, event.Interface())
+		}
+	}
+
+	return ran, nil
+}
+
+// prepareData runs the pre-decode step and the migrations on the payload data,
+// unless already done.
+func (r *Rebound) prepareData(eventName string, ms map[int]func(old []byte) ([]byte, error), p *payload) error {
+	if p.prepared {
+		return nil
+	}
+
+	if r.preDecode != nil {
+		if err := r.preDecodePayload(eventName, p); err != nil {
+			return err
+		}
+	}
+
+	if len(ms) > 0 {
+		if err := r.migratePayload(eventName, ms, p); err != nil {
+			return err
+		}
+	}
+
+	p.prepared = true
 	return nil
 }
 
-func (r *Rebound) decode(data []byte, v interface{}) error {
-	decoder := r.Decoder
-	if decoder == nil {
-		decoder = DefaultDecoder
+// decodeEvent decodes the payload into a new value of the event type.
+func (r *Rebound) decodeEvent(eventName string, eventType reflect.Type, ms map[int]func(old []byte) ([]byte, error), p *payload) (reflect.Value, error) {
+	// an interface event holds a concrete value that may be a pointer, and a
+	// map event is a reference, so they are not cached to avoid sharing them
+	iface := eventType.Kind() == reflect.Interface
+	shared := iface || eventType.Kind() == reflect.Map
+
+	// an interface{} event is decoded as is, such as into a
+	// map[string]interface{}, until concrete types are registered
+	polymorphic := iface && (eventType.NumMethod() > 0 || r.hasTypes())
+
+	var key decodeCacheKey
+	// the cache key does not tell the decoders apart, so only the events
+	// decoded by the Rebound decoder are cached, outside of the decoder scopes
+	cacheable := r.decodeCache != nil && p.rd == nil && p.dec == nil && !r.decoderScoped && !shared
+	if cacheable {
+		key = newDecodeCacheKey(eventName, eventType, r.hashData(p.data))
+		if event, ok := r.decodeCache.get(key); ok {
+			return event, nil
+		}
+	}
+
+	if err := r.prepareData(eventName, ms, p); err != nil {
+		return reflect.Value{}, err
+	}
+
+	var (
+		event reflect.Value
+		err   error
+	)
+
+	if polymorphic {
+		if _, err := p.bytes(eventName); err != nil {
+			return reflect.Value{}, err
+		}
+
+		event, err = r.decodeVariant(eventType, p)
+	} else {
+		if r.dataPath != "" {
+			if err := r.extractPayload(eventName, p); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+
+		ptr := reflect.New(eventType)
+		err = r.decodePayload(p, ptr.Interface())
+		event = ptr.Elem()
+	}
+
+	if limitErr := p.checkLimit(eventName); limitErr != nil {
+		return reflect.Value{}, limitErr
+	}
+
+	if err != nil {
+		// a partially decoded event is still handled when allowed, but not
+		// cached as the decoding failed
+		var partialErr PartialDecodeError
+		if r.partialDecode && errors.As(err, &partialErr) {
+			return event, DecodeError{EventName: eventName, Err: err}
+		}
+
+		return reflect.Value{}, DecodeError{EventName: eventName, Err: err}
+	}
+
+	if cacheable {
+		r.decodeCache.add(key, event)
+	}
+
+	return event, nil
+}
+
+// joinErrors is like errors.Join, but returns a single error as is.
+func joinErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	return errors.Join(errs...)
+}
+
+// Range calls fn sequentially for each registered event name, or pattern,
+// with its event type. If fn returns false, Range stops the iteration.
+//
+// The iteration order is unspecified. Handlers registered using ReactToFunc
+// have no event type, so they are not visited.
+func (r *Rebound) Range(fn func(eventName string, eventType reflect.Type) bool) {
+	type entry struct {
+		eventName string
+		eventType reflect.Type
+	}
+
+	r.mu.RLock()
+	entries := make([]entry, 0, len(r.reg.handlers)+len(r.reg.patterns))
+	for eventName, hs := range r.reg.handlers {
+		entries = append(entries, entry{eventName: eventName, eventType: hs[0].eventType})
+	}
+
+	for _, ph := range r.reg.patterns {
+		entries = append(entries, entry{eventName: ph.pattern, eventType: ph.h.eventType})
+	}
+	r.mu.RUnlock()
+
+	for _, e := range entries {
+		if !fn(e.eventName, e.eventType) {
+			return
+		}
+	}
+}
+
+// Route describes an event handled by a Rebound.
+type Route struct {
+	EventName string `json:"eventName"`
+
+	// EventType is the name of the event type, or empty for handlers
+	// registered using ReactToFunc.
+	EventType string `json:"eventType,omitempty"`
+
+	// Wildcard indicates the EventName is a pattern registered using
+	// ReactToPattern or ReactToRemaining, or a regular expression registered
+	// using ReactToRegexp.
+	Wildcard bool `json:"wildcard,omitempty"`
+
+	// Description is the description of the event registered using
+	// ReactToWithDesc.
+	Description string `json:"description,omitempty"`
+}
+
+// ExportRoutes returns the routes of the registered handlers, sorted by event
+// name.
+func (r *Rebound) ExportRoutes() []Route {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routes := make([]Route, 0, len(r.reg.handlers)+len(r.reg.funcs)+len(r.reg.patterns))
+	for eventName, hs := range r.reg.handlers {
+		routes = append(routes, Route{EventName: eventName, EventType: hs[0].eventType.String(), Description: describe(hs)})
+	}
+
+	for eventName := range r.reg.funcs {
+		routes = append(routes, Route{EventName: eventName})
+	}
+
+	for _, ph := range r.reg.patterns {
+		routes = append(routes, Route{EventName: ph.pattern, EventType: ph.h.eventType.String(), Wildcard: true})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].EventName < routes[j].EventName
+	})
+
+	return routes
+}
+
+// Warmup prepares the registered event types for decoding, so the first
+// dispatch of an event is as fast as the subsequent ones. Call it after
+// registering the handlers, typically at startup.
+//
+// The handler reflection metadata is already computed on registration, so
+// Warmup primes the per-type cache of the JSON decoder when it is in use.
+func (r *Rebound) Warmup() {
+	if _, ok := r.decoder().(jsonDecoder); !ok {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, hs := range r.reg.handlers {
+		event := reflect.New(hs[0].eventType)
+		_ = json.Unmarshal([]byte("{}"), event.Interface())
+	}
+}
+
+// WithDecoderScope sets the decoder to dec while running fn, then restores
+// the previous decoder, even if fn panics. Like setting Decoder, it must not
+// run concurrently with the dispatches outside of fn. The decoded events cache
+// (see WithDecodeCache) is not used within fn.
+func (r *Rebound) WithDecoderScope(dec Decoder, fn func()) {
+	prev, prevScoped := r.Decoder, r.decoderScoped
+	r.Decoder, r.decoderScoped = dec, true
+	defer func() {
+		r.Decoder, r.decoderScoped = prev, prevScoped
+	}()
+
+	fn()
+}
+
+// decoder returns the Rebound decoder, defaulting to DefaultDecoder. The
+// JSONDecoder is returned as a jsonDecoder.
+func (r *Rebound) decoder() Decoder {
+	dec := r.Decoder
+	if dec == nil {
+		dec = DefaultDecoder
+	}
+
+	if isJSONDecoder(dec) {
+		return jsonDecoder{}
+	}
+
+	return dec
+}
+
+func (r *Rebound) decodePayload(p *payload, v interface{}) error {
+	if r.allowEmptyData && p.empty() {
+		return nil
+	}
+
+	decoder := r.payloadDecoder(p)
+	if p.rd != nil {
+		return decodeReader(decoder, p.rd, v)
+	}
+
+	return decoder.Decode(p.data, v)
+}
+
+// payloadDecoder returns the decoder of the payload, defaulting to the
+// Rebound decoder.
+func (r *Rebound) payloadDecoder(p *payload) Decoder {
+	decoder := p.dec
+	switch {
+	case decoder == nil:
+		decoder = r.decoder()
+	case isJSONDecoder(decoder):
+		decoder = jsonDecoder{}
+	}
+
+	if _, ok := decoder.(jsonDecoder); ok && r.useNumber {
+		return jsonNumberDecoder{}
+	}
+
+	return decoder
+}
+
+func decodeReader(decoder Decoder, rd io.Reader, v interface{}) error {
+	if rdec, ok := decoder.(ReaderDecoder); ok {
+		return rdec.DecodeReader(rd, v)
+	}
+
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return err
 	}
 
 	return decoder.Decode(data, v)
 }
 
+// validateHandler is like ValidateHandler, unless the validation is skipped
+// (see WithSkipValidation).
+func (r *Rebound) validateHandler(fn EventHandler) error {
+	if r.skipValidation {
+		return nil
+	}
+
+	return ValidateHandler(fn)
+}
+
+// InvalidReason is the reason why a function is not a valid EventHandler.
+type InvalidReason int
+
+// The reasons reported by InvalidHandlerError.
+const (
+	ReasonNotFunc InvalidReason = iota + 1
+	ReasonInputCount
+	ReasonOutputCount
+	ReasonEventType
+	ReasonInputs
+	ReasonErrorOutput
+)
+
+func (r InvalidReason) String() string {
+	switch r {
+	case ReasonNotFunc:
+		return "not a function"
+	case ReasonInputCount:
+		return "input count"
+	case ReasonOutputCount:
+		return "output count"
+	case ReasonEventType:
+		return "event type"
+	case ReasonInputs:
+		return "inputs"
+	case ReasonErrorOutput:
+		return "error output"
+	default:
+		return fmt.Sprintf("InvalidReason(%d)", int(r))
+	}
+}
+
+// InvalidHandlerError indicates that a function is not a valid EventHandler,
+// for the given reason.
+type InvalidHandlerError struct {
+	Reason InvalidReason
+	msg    string
+}
+
+func invalidHandler(reason InvalidReason, format string, args ...interface{}) InvalidHandlerError {
+	return InvalidHandlerError{Reason: reason, msg: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the error message for InvalidHandlerError.
+func (e InvalidHandlerError) Error() string {
+	return e.msg
+}
+
 // ValidateHandler checks if the provided function is a valid EventHandler.
-// Returns an error if the function does not have the expected signature.
+// Returns an InvalidHandlerError if the function does not have the expected
+// signature.
 func ValidateHandler(fn EventHandler) error {
 	fnType := reflect.TypeOf(fn)
-	if fnType.Kind() != reflect.Func {
-		return fmt.Errorf("rebound: fn EventHandler is not a function (got: %v)", fnType.Kind())
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		var kind reflect.Kind
+		if fnType != nil {
+			kind = fnType.Kind()
+		}
+
+		return invalidHandler(ReasonNotFunc, "rebound: fn EventHandler is not a function (got: %v)", kind)
 	}
 
-	if fnType.NumIn() != 1 {
-		return fmt.Errorf("rebound: fn EventHandler should have 1 input parameter (got: %d)", fnType.NumIn())
+	if fnType.NumIn() < 1 {
+		return invalidHandler(ReasonInputCount, "rebound: fn EventHandler should have at least 1 input parameter (got: %d)", fnType.NumIn())
 	}
 
-	if fnType.NumOut() != 1 {
-		return fmt.Errorf("rebound: fn EventHandler should have 1 output parameter (got: %d)", fnType.NumOut())
+	if fnType.NumOut() < 1 {
+		return invalidHandler(ReasonOutputCount, "rebound: fn EventHandler should have at least 1 output parameter (got: %d)", fnType.NumOut())
 	}
 
-	if fnType.In(0).Kind() != reflect.Struct {
-		return fmt.Errorf("rebound: fn EventHandler input parameter should be a struct (got: %v)", fnType.In(0).Kind())
+	in := parseInputs(fnType)
+	switch eventType := fnType.In(in.event); eventType.Kind() {
+	case reflect.Struct, reflect.Interface, reflect.Map:
+	default:
+		return invalidHandler(ReasonEventType, "rebound: fn EventHandler event input parameter should be a struct, a map or an interface (got: %v)", eventType.Kind())
 	}
 
-	if fnType.Out(0) != reflect.TypeOf((*error)(nil)).Elem() {
-		return fmt.Errorf("rebound: fn EventHandler output parameter should be an error (got: %v)", fnType.Out(0))
+	last := in.event
+	if in.raw {
+		last++
+	}
+
+	if last != fnType.NumIn()-1 {
+		return invalidHandler(ReasonInputs, "rebound: fn EventHandler input parameters should be ([ctx context.Context,] [key []byte,] event [, raw []byte]) (got: %v)", fnType)
+	}
+
+	var errCount int
+	for i := 0; i < fnType.NumOut(); i++ {
+		if fnType.Out(i) == errorType {
+			errCount++
+		}
+	}
+
+	if errCount != 1 {
+		return invalidHandler(ReasonErrorOutput, "rebound: fn EventHandler should have exactly 1 error output parameter (got: %d)", errCount)
 	}
 
 	return nil
 }
 
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	bytesType   = reflect.TypeOf([]byte(nil))
+)
+
+// handlerInputs describes the input parameters of an EventHandler.
+type handlerInputs struct {
+	ctx   bool // has a leading context.Context
+	key   bool // has a []byte key before the event
+	event int  // index of the event
+	raw   bool // has a []byte raw data after the event
+}
+
+// parseInputs locates the input parameters of fnType, which has at least one
+// input parameter.
+func parseInputs(fnType reflect.Type) handlerInputs {
+	var in handlerInputs
+
+	if fnType.NumIn() > 1 && fnType.In(in.event) == contextType {
+		in.ctx = true
+		in.event++
+	}
+
+	if fnType.NumIn() > in.event+1 && fnType.In(in.event) == bytesType {
+		in.key = true
+		in.event++
+	}
+
+	if fnType.NumIn() == in.event+2 && fnType.In(in.event+1) == bytesType {
+		in.raw = true
+	}
+
+	return in
+}
+
+// errorOutIndex returns the index of the error output parameter of fnType.
+func errorOutIndex(fnType reflect.Type) int {
+	for i := 0; i < fnType.NumOut(); i++ {
+		if fnType.Out(i) == errorType {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // Decoder defines an interface for decoding event data.
 type Decoder interface {
 	// Decode decodes data into the provided interface.
@@ -142,8 +1348,84 @@ func (f DecodeFunc) Decode(data []byte, v interface{}) error {
 	return f(data, v)
 }
 
+// ReaderDecoder is a Decoder that can decode data directly from an io.Reader.
+type ReaderDecoder interface {
+	Decoder
+
+	// DecodeReader decodes data read from rd into the provided interface.
+	DecodeReader(rd io.Reader, v interface{}) error
+}
+
 // JSONDecoder is a Decoder implementation using JSON.
 var JSONDecoder = DecodeFunc(json.Unmarshal)
 
 // DefaultDecoder is the default decoder used if none is specified.
 var DefaultDecoder = JSONDecoder
+
+// jsonUnmarshal identifies json.Unmarshal, the function of JSONDecoder.
+var jsonUnmarshal = reflect.ValueOf(json.Unmarshal).Pointer()
+
+// isJSONDecoder reports whether dec is JSONDecoder, that is json.Unmarshal.
+// A DecodeFunc wrapping any other function is not.
+func isJSONDecoder(dec Decoder) bool {
+	f, ok := dec.(DecodeFunc)
+	return ok && f != nil && reflect.ValueOf(f).Pointer() == jsonUnmarshal
+}
+
+// jsonDecoder is JSONDecoder, also implementing ReaderDecoder and
+// TypeChecker. It is used in place of JSONDecoder, so DispatchReader streams
+// with the default decoder.
+type jsonDecoder struct{}
+
+func (jsonDecoder) Decode(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonDecoder) DecodeReader(rd io.Reader, v interface{}) error {
+	return json.NewDecoder(rd).Decode(v)
+}
+
+func (jsonDecoder) CheckType(t reflect.Type) error {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return fmt.Errorf("json cannot decode into %v", t.Kind())
+	}
+
+	return nil
+}
+
+// jsonNumberDecoder is like jsonDecoder, but decodes the numbers of interface
+// values as json.Number (see WithUseNumber).
+type jsonNumberDecoder struct{}
+
+func (d jsonNumberDecoder) Decode(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid data after top-level value")
+	}
+
+	return nil
+}
+
+func (jsonNumberDecoder) DecodeReader(rd io.Reader, v interface{}) error {
+	dec := json.NewDecoder(rd)
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// With registers an event handler for a given event name, like ReactTo, and
+// returns r, so the registrations can be chained:
+//
+//	rb.With("order.completed", onOrderCompleted).
+//		With("order.shipped", onOrderShipped)
+//
+// It panics if the event already has a handler.
+func (r *Rebound) With(eventName string, fn EventHandler) *Rebound {
+	r.ReactTo(eventName, fn)
+	return r
+}
@@ -1,9 +1,17 @@
 package rebound_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/uudashr/rebound"
 )
@@ -49,3 +57,1844 @@ func TestNatsSubjectParsing(t *testing.T) {
 		t.Errorf("got %q, want %q", got, want)
 	}
 }
+
+func TestRange(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	type OrderCanceled struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+	rb.ReactTo("order.canceled", func(event OrderCanceled) error { return nil })
+
+	got := make(map[string]reflect.Type)
+	rb.Range(func(eventName string, eventType reflect.Type) bool {
+		got[eventName] = eventType
+		return true
+	})
+
+	want := map[string]reflect.Type{
+		"order.completed": reflect.TypeOf(OrderCompleted{}),
+		"order.canceled":  reflect.TypeOf(OrderCanceled{}),
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRange_stopEarly(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+	rb.ReactTo("order.canceled", func(event OrderCompleted) error { return nil })
+	rb.ReactTo("order.shipped", func(event OrderCompleted) error { return nil })
+
+	var count int
+	rb.Range(func(eventName string, eventType reflect.Type) bool {
+		count++
+		return false
+	})
+
+	if got, want := count, 1; got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+type ResultCode int
+
+func TestDispatch_errorFirstOutput(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	handlerErr := errors.New("handler error")
+
+	var gotOrderID string
+	rb.ReactTo("order.completed", func(event OrderCompleted) (error, ResultCode) {
+		gotOrderID = event.OrderID
+		return handlerErr, 1
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if got, want := err, handlerErr; !errors.Is(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, want := gotOrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDispatch_errorSecondOutput(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	handlerErr := errors.New("handler error")
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) (ResultCode, error) {
+		return 1, handlerErr
+	})
+
+	rb.ReactTo("order.canceled", func(event OrderCompleted) (ResultCode, error) {
+		return 0, nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if got, want := err, handlerErr; !errors.Is(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	err = rb.Dispatch("order.canceled", []byte(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateHandler(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	testCases := map[string]struct {
+		fn      rebound.EventHandler
+		wantErr bool
+	}{
+		"single error": {
+			fn: func(event OrderCompleted) error { return nil },
+		},
+		"error first": {
+			fn: func(event OrderCompleted) (error, ResultCode) { return nil, 0 },
+		},
+		"error second": {
+			fn: func(event OrderCompleted) (ResultCode, error) { return 0, nil },
+		},
+		"not a function": {
+			fn:      "not a function",
+			wantErr: true,
+		},
+		"no output": {
+			fn:      func(event OrderCompleted) {},
+			wantErr: true,
+		},
+		"no error output": {
+			fn:      func(event OrderCompleted) ResultCode { return 0 },
+			wantErr: true,
+		},
+		"multiple error outputs": {
+			fn:      func(event OrderCompleted) (error, error) { return nil, nil },
+			wantErr: true,
+		},
+		"non-struct input": {
+			fn:      func(event string) error { return nil },
+			wantErr: true,
+		},
+		"interface input": {
+			fn: func(event fmt.Stringer) error { return nil },
+		},
+		"with context": {
+			fn: func(ctx context.Context, event OrderCompleted) error { return nil },
+		},
+		"non-context first input": {
+			fn:      func(s string, event OrderCompleted) error { return nil },
+			wantErr: true,
+		},
+		"too many inputs": {
+			fn:      func(ctx context.Context, s string, event OrderCompleted) error { return nil },
+			wantErr: true,
+		},
+		"with key": {
+			fn: func(key []byte, event OrderCompleted) error { return nil },
+		},
+		"with context and key": {
+			fn: func(ctx context.Context, key []byte, event OrderCompleted) error { return nil },
+		},
+		"with raw": {
+			fn: func(event OrderCompleted, raw []byte) error { return nil },
+		},
+		"with context, key and raw": {
+			fn: func(ctx context.Context, key []byte, event OrderCompleted, raw []byte) error { return nil },
+		},
+		"input after raw": {
+			fn:      func(event OrderCompleted, raw []byte, s string) error { return nil },
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := rebound.ValidateHandler(tc.fn)
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Errorf("got error %v, want error %t", err, want)
+			}
+		})
+	}
+}
+
+func TestValidateHandler_reason(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	testCases := map[string]struct {
+		fn   rebound.EventHandler
+		want rebound.InvalidReason
+	}{
+		"nil":                    {fn: nil, want: rebound.ReasonNotFunc},
+		"not a function":         {fn: "not a function", want: rebound.ReasonNotFunc},
+		"no input":               {fn: func() error { return nil }, want: rebound.ReasonInputCount},
+		"no output":              {fn: func(event OrderCompleted) {}, want: rebound.ReasonOutputCount},
+		"non-struct input":       {fn: func(event string) error { return nil }, want: rebound.ReasonEventType},
+		"input after raw":        {fn: func(event OrderCompleted, raw []byte, s string) error { return nil }, want: rebound.ReasonInputs},
+		"no error output":        {fn: func(event OrderCompleted) ResultCode { return 0 }, want: rebound.ReasonErrorOutput},
+		"multiple error outputs": {fn: func(event OrderCompleted) (error, error) { return nil, nil }, want: rebound.ReasonErrorOutput},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := rebound.ValidateHandler(tc.fn)
+
+			var invalidErr rebound.InvalidHandlerError
+			if !errors.As(err, &invalidErr) {
+				t.Fatalf("got error %v, want %T", err, invalidErr)
+			}
+
+			if got := invalidErr.Reason; got != tc.want {
+				t.Errorf("got reason %v, want %v", got, tc.want)
+			}
+
+			if !strings.HasPrefix(err.Error(), "rebound: fn EventHandler ") {
+				t.Errorf("got message %q, want a human-readable message", err.Error())
+			}
+		})
+	}
+}
+
+func TestInvalidReason_String(t *testing.T) {
+	testCases := map[rebound.InvalidReason]string{
+		rebound.ReasonNotFunc:     "not a function",
+		rebound.ReasonInputCount:  "input count",
+		rebound.ReasonOutputCount: "output count",
+		rebound.ReasonEventType:   "event type",
+		rebound.ReasonInputs:      "inputs",
+		rebound.ReasonErrorOutput: "error output",
+		rebound.InvalidReason(42): "InvalidReason(42)",
+	}
+
+	for reason, want := range testCases {
+		if got := reason.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestDispatchReader(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var gotOrderID string
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		gotOrderID = event.OrderID
+		return nil
+	})
+
+	err := rb.DispatchReader("order.completed", strings.NewReader(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotOrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestJSONDecoder(t *testing.T) {
+	// JSONDecoder is a DecodeFunc, callable like json.Unmarshal
+	var dec rebound.DecodeFunc = rebound.JSONDecoder
+
+	var event OrderEvent
+	if err := dec([]byte(`{"OrderID":"123"}`), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := event.OrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDefaultDecoder(t *testing.T) {
+	defer func(prev rebound.DecodeFunc) {
+		rebound.DefaultDecoder = prev
+	}(rebound.DefaultDecoder)
+
+	var calls int
+	rebound.DefaultDecoder = rebound.DecodeFunc(func(data []byte, v interface{}) error {
+		calls++
+		return json.Unmarshal(data, v)
+	})
+
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.DispatchReader("order.completed", strings.NewReader(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := calls, 2; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestDispatchReader_nonReaderDecoder(t *testing.T) {
+	var gotData string
+	rb := &rebound.Rebound{
+		Decoder: rebound.DecodeFunc(func(data []byte, v interface{}) error {
+			gotData = string(data)
+			return json.Unmarshal(data, v)
+		}),
+	}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var gotOrderID string
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		gotOrderID = event.OrderID
+		return nil
+	})
+
+	err := rb.DispatchReader("order.completed", strings.NewReader(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotData, `{"OrderID":"123"}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, want := gotOrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDispatch_panicOnUnhandled(t *testing.T) {
+	rb := rebound.New(rebound.WithPanicOnUnhandled(true))
+
+	defer func() {
+		got := recover()
+		if got == nil {
+			t.Fatal("expected panic")
+		}
+
+		if want := (rebound.NoHandlerError{EventName: "order.completed"}).Error(); got != want {
+			t.Errorf("got %v, want %q", got, want)
+		}
+	}()
+
+	rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+}
+
+func TestDispatch_noPanicOnUnhandled(t *testing.T) {
+	rb := rebound.New(rebound.WithPanicOnUnhandled(false))
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+
+	var noHandlerErr rebound.NoHandlerError
+	if !errors.As(err, &noHandlerErr) {
+		t.Fatalf("got %v, want NoHandlerError", err)
+	}
+
+	if got, want := noHandlerErr.EventName, "order.completed"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReactToWithPriority(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var calls []string
+	handle := func(name string) func(event OrderCompleted) error {
+		return func(event OrderCompleted) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	rb.ReactTo("order.completed", handle("main"))
+	rb.ReactToWithPriority("order.completed", 100, handle("audit"))
+	rb.ReactToWithPriority("order.completed", -10, handle("validate"))
+	rb.ReactToWithPriority("order.completed", 0, handle("notify"))
+	rb.ReactToWithPriority("order.completed", 100, handle("archive"))
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"validate", "main", "notify", "audit", "archive"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+}
+
+func TestReactToWithPriority_errors(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+
+	var called bool
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderCompleted) error { return err1 })
+	rb.ReactToWithPriority("order.completed", 2, func(event OrderCompleted) error {
+		called = true
+		return nil
+	})
+	rb.ReactToWithPriority("order.completed", 3, func(event OrderCompleted) error { return err2 })
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("got %v, want both %v and %v", err, err1, err2)
+	}
+
+	if !called {
+		t.Error("expected handler to be called")
+	}
+}
+
+func TestReactToWithPriority_eventTypeMismatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	type OrderCanceled struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderCanceled) error { return nil })
+}
+
+func TestReactToFunc(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var gotData string
+	rb.ReactToFunc("order.completed", func(data []byte) error {
+		gotData = string(data)
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotData, `{"OrderID":"123"}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	handlerErr := errors.New("handler error")
+	rb.ReactToFunc("order.canceled", func(data []byte) error {
+		return handlerErr
+	})
+
+	err = rb.DispatchReader("order.canceled", strings.NewReader(`{"OrderID":"123"}`))
+	if got, want := err, handlerErr; !errors.Is(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReactToFunc_duplicate(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	rb.ReactToFunc("order.completed", func(data []byte) error { return nil })
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+
+	data := []byte(`{"OrderID":"123"}`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDispatch_func(b *testing.B) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactToFunc("order.completed", func(data []byte) error {
+		var event OrderCompleted
+		return json.Unmarshal(data, &event)
+	})
+
+	data := []byte(`{"OrderID":"123"}`)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	type observed struct {
+		eventName string
+		data      string
+		kind      string
+	}
+
+	var got []observed
+	rb := rebound.New(rebound.WithErrorHandler(func(eventName string, data []byte, err error) {
+		var (
+			noHandlerErr rebound.NoHandlerError
+			decodeErr    rebound.DecodeError
+		)
+
+		kind := "handler"
+		switch {
+		case errors.As(err, &noHandlerErr):
+			kind = "no-handler"
+		case errors.As(err, &decodeErr):
+			kind = "decode"
+		}
+
+		got = append(got, observed{eventName: eventName, data: string(data), kind: kind})
+	}))
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		if event.OrderID == "" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); !errors.Is(err, handlerErr) {
+		t.Errorf("got %v, want %v", err, handlerErr)
+	}
+
+	var decodeErr rebound.DecodeError
+	if err := rb.Dispatch("order.completed", []byte(`{`)); !errors.As(err, &decodeErr) {
+		t.Errorf("got %v, want DecodeError", err)
+	}
+
+	var noHandlerErr rebound.NoHandlerError
+	if err := rb.Dispatch("order.canceled", []byte(`{}`)); !errors.As(err, &noHandlerErr) {
+		t.Errorf("got %v, want NoHandlerError", err)
+	}
+
+	want := []observed{
+		{eventName: "order.completed", data: `{}`, kind: "handler"},
+		{eventName: "order.completed", data: `{`, kind: "decode"},
+		{eventName: "order.canceled", data: `{}`, kind: "no-handler"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDispatchAsync_synchronous(t *testing.T) {
+	rb := rebound.New(rebound.WithSynchronous(true))
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var gotOrderID string
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		gotOrderID = event.OrderID
+		return nil
+	})
+
+	errc := rb.DispatchAsync("order.completed", []byte(`{"OrderID":"123"}`))
+
+	// handled inline, no need to wait for the result
+	if got, want := gotOrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if err := <-errc; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDispatchAsync(t *testing.T) {
+	rb := rebound.New(rebound.WithSynchronous(false))
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	release := make(chan struct{})
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		<-release
+		return handlerErr
+	})
+
+	errc := rb.DispatchAsync("order.completed", []byte(`{"OrderID":"123"}`))
+
+	select {
+	case err := <-errc:
+		t.Fatalf("got result %v before the handler completes", err)
+	default:
+	}
+
+	close(release)
+
+	if got, want := <-errc, handlerErr; !errors.Is(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReactToMany(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderEvent struct {
+		OrderID string
+	}
+
+	var orderIDs []string
+	rb.ReactToMany([]string{"order.created", "order.recreated", "order.restored"}, func(event OrderEvent) error {
+		orderIDs = append(orderIDs, event.OrderID)
+		return nil
+	})
+
+	for i, eventName := range []string{"order.created", "order.recreated", "order.restored"} {
+		err := rb.Dispatch(eventName, []byte(fmt.Sprintf(`{"OrderID":"%d"}`, i)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got, want := strings.Join(orderIDs, ","), "0,1,2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReactToMany_duplicate(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderEvent struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.recreated", func(event OrderEvent) error { return nil })
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+
+		rb.ReactToMany([]string{"order.created", "order.recreated"}, func(event OrderEvent) error { return nil })
+	}()
+
+	var noHandlerErr rebound.NoHandlerError
+	if err := rb.Dispatch("order.created", []byte(`{}`)); !errors.As(err, &noHandlerErr) {
+		t.Errorf("got %v, want NoHandlerError", err)
+	}
+}
+
+func TestWithMaxPayloadSize(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	data := []byte(`{"OrderID":"123"}`) // 17 bytes
+
+	testCases := map[string]struct {
+		limit    int
+		tooLarge bool
+	}{
+		"under": {limit: len(data) + 1},
+		"at":    {limit: len(data)},
+		"over":  {limit: len(data) - 1, tooLarge: true},
+	}
+
+	dispatchers := map[string]func(rb *rebound.Rebound) error{
+		"Dispatch": func(rb *rebound.Rebound) error {
+			return rb.Dispatch("order.completed", data)
+		},
+		"DispatchReader": func(rb *rebound.Rebound) error {
+			return rb.DispatchReader("order.completed", bytes.NewReader(data))
+		},
+	}
+
+	for name, tc := range testCases {
+		for dispatcherName, dispatch := range dispatchers {
+			t.Run(name+"/"+dispatcherName, func(t *testing.T) {
+				rb := rebound.New(rebound.WithMaxPayloadSize(tc.limit))
+
+				var called bool
+				rb.ReactTo("order.completed", func(event OrderCompleted) error {
+					called = true
+					return nil
+				})
+
+				err := dispatch(rb)
+
+				var tooLargeErr rebound.PayloadTooLargeError
+				if got, want := errors.As(err, &tooLargeErr), tc.tooLarge; got != want {
+					t.Fatalf("got error %v, want PayloadTooLargeError %t", err, want)
+				}
+
+				if got, want := called, !tc.tooLarge; got != want {
+					t.Errorf("got called %t, want %t", got, want)
+				}
+
+				if tc.tooLarge {
+					if got, want := tooLargeErr.Limit, tc.limit; got != want {
+						t.Errorf("got limit %d, want %d", got, want)
+					}
+
+					if tooLargeErr.Size <= tc.limit {
+						t.Errorf("got size %d, want greater than %d", tooLargeErr.Size, tc.limit)
+					}
+				}
+			})
+		}
+	}
+}
+
+type OrderShipped struct {
+	OrderID string
+}
+
+func TestExportRoutes(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	rb.ReactTo("order.shipped", func(event OrderShipped) error { return nil })
+	rb.ReactToWithPriority("order.shipped", 1, func(event OrderShipped) error { return nil })
+	rb.ReactToFunc("order.completed", func(data []byte) error { return nil })
+	rb.ReactToPattern("order.*", func(event OrderShipped) error { return nil })
+
+	want := []rebound.Route{
+		{EventName: "order.*", EventType: "rebound_test.OrderShipped", Wildcard: true},
+		{EventName: "order.completed"},
+		{EventName: "order.shipped", EventType: "rebound_test.OrderShipped"},
+	}
+
+	if got := rb.ExportRoutes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestReactToIf(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+		Amount  int
+	}
+
+	var gotOrderIDs []string
+	rb.ReactToIf("order.completed", func(event interface{}) bool {
+		return event.(OrderCompleted).Amount >= 100
+	}, func(event OrderCompleted) error {
+		gotOrderIDs = append(gotOrderIDs, event.OrderID)
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"1","Amount":150}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = rb.Dispatch("order.completed", []byte(`{"OrderID":"2","Amount":50}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := strings.Join(gotOrderIDs, ","), "1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTryDispatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+		Amount  int
+	}
+
+	handlerErr := errors.New("handler error")
+	rb.ReactToIf("order.completed", func(event interface{}) bool {
+		return event.(OrderCompleted).Amount > 0
+	}, func(event OrderCompleted) error {
+		if event.OrderID == "" {
+			return handlerErr
+		}
+
+		return nil
+	})
+
+	testCases := map[string]struct {
+		data    string
+		wantRan bool
+		wantErr error
+	}{
+		"success": {data: `{"OrderID":"123","Amount":100}`, wantRan: true},
+		"skip":    {data: `{"OrderID":"123","Amount":0}`, wantRan: false},
+		"error":   {data: `{"Amount":100}`, wantRan: true, wantErr: handlerErr},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ran, err := rb.TryDispatch(context.Background(), "order.completed", []byte(tc.data))
+			if got, want := ran, tc.wantRan; got != want {
+				t.Errorf("got ran %t, want %t", got, want)
+			}
+
+			if got, want := err, tc.wantErr; !errors.Is(got, want) {
+				t.Errorf("got error %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTryDispatch_canceled(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran, err := rb.TryDispatch(ctx, "order.completed", []byte(`{"OrderID":"123"}`))
+	if ran {
+		t.Error("got ran, want not ran")
+	}
+
+	if got, want := err, context.Canceled; got != want {
+		t.Errorf("got error %v, want %v", got, want)
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	var decodeCount int
+	rb := &rebound.Rebound{
+		Decoder: rebound.DecodeFunc(func(data []byte, v interface{}) error {
+			decodeCount++
+			return json.Unmarshal(data, v)
+		}),
+	}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var called bool
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		called = true
+		return nil
+	})
+
+	rb.Warmup()
+
+	if called {
+		t.Error("got handler called on warmup")
+	}
+
+	if got, want := decodeCount, 0; got != want {
+		t.Errorf("got %d decodes on warmup with a custom decoder, want %d", got, want)
+	}
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Error("expected handler to be called")
+	}
+}
+
+func BenchmarkDispatch_first(b *testing.B) {
+	b.Run("cold", func(b *testing.B) {
+		benchmarkFirstDispatch(b, false)
+	})
+
+	b.Run("warmup", func(b *testing.B) {
+		benchmarkFirstDispatch(b, true)
+	})
+}
+
+var benchmarkTypeSeq int
+
+// benchmarkFirstDispatch measures the first dispatch of an event, using a
+// new event type on each iteration so no type cache carries over.
+func benchmarkFirstDispatch(b *testing.B, warmup bool) {
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	data := []byte(`{"OrderID":"123","CustomerID":"456","Amount":100}`)
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		benchmarkTypeSeq++
+		tag := reflect.StructTag(fmt.Sprintf(`seq:"%d"`, benchmarkTypeSeq))
+		eventType := reflect.StructOf([]reflect.StructField{
+			{Name: "OrderID", Type: reflect.TypeOf(""), Tag: tag},
+			{Name: "CustomerID", Type: reflect.TypeOf("")},
+			{Name: "Amount", Type: reflect.TypeOf(0)},
+		})
+
+		fnType := reflect.FuncOf([]reflect.Type{eventType}, []reflect.Type{errType}, false)
+		fn := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.Zero(errType)}
+		})
+
+		rb := &rebound.Rebound{}
+		rb.ReactTo("order.completed", fn.Interface())
+		if warmup {
+			rb.Warmup()
+		}
+
+		b.StartTimer()
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDispatch_decodeErrorNamesEvent(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	newRebound := func() *rebound.Rebound {
+		rb := &rebound.Rebound{}
+		rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+		rb.ReactToPattern("order.*", func(event OrderCompleted) error { return nil })
+		rb.RegisterMigration("order.migrated", 1, func(old []byte) ([]byte, error) { return old, nil })
+		rb.ReactTo("order.migrated", func(event OrderCompleted) error { return nil })
+		return rb
+	}
+
+	data := []byte(`{"OrderID":`)
+
+	testCases := map[string]struct {
+		eventName string
+		dispatch  func(rb *rebound.Rebound, eventName string) error
+	}{
+		"Dispatch": {
+			eventName: "order.completed",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				return rb.Dispatch(eventName, data)
+			},
+		},
+		"Dispatch pattern": {
+			eventName: "order.canceled",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				return rb.Dispatch(eventName, data)
+			},
+		},
+		"Dispatch migration": {
+			eventName: "order.migrated",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				return rb.Dispatch(eventName, data)
+			},
+		},
+		"DispatchReader": {
+			eventName: "order.completed",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				return rb.DispatchReader(eventName, bytes.NewReader(data))
+			},
+		},
+		"TryDispatch": {
+			eventName: "order.completed",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				_, err := rb.TryDispatch(context.Background(), eventName, data)
+				return err
+			},
+		},
+		"DispatchAsync": {
+			eventName: "order.completed",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				return <-rb.DispatchAsync(eventName, data)
+			},
+		},
+		"DispatchNDJSON": {
+			eventName: "order.completed",
+			dispatch: func(rb *rebound.Rebound, eventName string) error {
+				line := eventName + "\t" + string(data)
+				return errors.Join(rb.DispatchNDJSON(strings.NewReader(line), parseTabSeparated)...)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.dispatch(newRebound(), tc.eventName)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if got, want := err.Error(), strconv.Quote(tc.eventName); !strings.Contains(got, want) {
+				t.Errorf("got %q, want it to contain %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDispatchReader_readErrorNamesEvent(t *testing.T) {
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	readErr := errors.New("connection reset")
+
+	testCases := map[string]struct {
+		opts  []rebound.Option
+		setup func(rb *rebound.Rebound)
+	}{
+		"data path": {
+			opts: []rebound.Option{rebound.WithDataPath("data")},
+			setup: func(rb *rebound.Rebound) {
+				rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+			},
+		},
+		"pre-decode": {
+			opts: []rebound.Option{rebound.WithPreDecode(func(eventName string, data []byte) ([]byte, error) { return data, nil })},
+			setup: func(rb *rebound.Rebound) {
+				rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+			},
+		},
+		"migration": {
+			setup: func(rb *rebound.Rebound) {
+				rb.RegisterMigration("order.completed", 1, func(old []byte) ([]byte, error) { return old, nil })
+				rb.ReactTo("order.completed", func(event OrderCompleted) error { return nil })
+			},
+		},
+		"raw func": {
+			setup: func(rb *rebound.Rebound) {
+				rb.ReactToFunc("order.completed", func(data []byte) error { return nil })
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(tc.opts...)
+			tc.setup(rb)
+
+			err := rb.DispatchReader("order.completed", iotest.ErrReader(readErr))
+			if !errors.Is(err, readErr) {
+				t.Fatalf("got %v, want %v", err, readErr)
+			}
+
+			if !errors.Is(err, rebound.ErrDecode) {
+				t.Errorf("got %v, want it to match ErrDecode", err)
+			}
+
+			var decodeErr rebound.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("got %T, want DecodeError", err)
+			}
+
+			if got, want := decodeErr.EventName, "order.completed"; got != want {
+				t.Errorf("got event name %q, want %q", got, want)
+			}
+		})
+	}
+}
+func TestDispatchContext(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	type ctxKey struct{}
+
+	var gotValue interface{}
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderCompleted) error {
+		gotValue = ctx.Value(ctxKey{})
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	if err := rb.DispatchContext(ctx, "order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotValue, "value"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDispatchContext_cancelMultiHandler(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlerErr := errors.New("handler error")
+
+	var calls []string
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderCompleted) error {
+		calls = append(calls, "first")
+		return handlerErr
+	})
+
+	rb.ReactToWithPriority("order.completed", 2, func(ctx context.Context, event OrderCompleted) error {
+		calls = append(calls, "second")
+		cancel()
+		return nil
+	})
+
+	rb.ReactToWithPriority("order.completed", 3, func(event OrderCompleted) error {
+		calls = append(calls, "third")
+		return nil
+	})
+
+	err := rb.DispatchContext(ctx, "order.completed", []byte(`{"OrderID":"123"}`))
+	if !errors.Is(err, handlerErr) || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want both %v and %v", err, handlerErr, context.Canceled)
+	}
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+}
+
+func TestDispatchKeyed(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	var (
+		gotKey     string
+		gotOrderID string
+	)
+	rb.ReactTo("order.completed", func(key []byte, event OrderCompleted) error {
+		gotKey = string(key)
+		gotOrderID = event.OrderID
+		return nil
+	})
+
+	var gotCtxKey string
+	rb.ReactTo("order.canceled", func(ctx context.Context, key []byte, event OrderCompleted) error {
+		gotCtxKey = string(key)
+		return nil
+	})
+
+	var unkeyedCalled bool
+	rb.ReactTo("order.shipped", func(event OrderCompleted) error {
+		unkeyedCalled = true
+		return nil
+	})
+
+	if err := rb.DispatchKeyed("order.completed", []byte("customer-1"), []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotKey, "customer-1"; got != want {
+		t.Errorf("got key %q, want %q", got, want)
+	}
+
+	if got, want := gotOrderID, "123"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if err := rb.DispatchKeyed("order.canceled", []byte("customer-2"), []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := gotCtxKey, "customer-2"; got != want {
+		t.Errorf("got key %q, want %q", got, want)
+	}
+
+	if err := rb.DispatchKeyed("order.shipped", []byte("customer-3"), []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !unkeyedCalled {
+		t.Error("expected unkeyed handler to be called")
+	}
+}
+
+func TestDispatch_keyedHandlerWithoutKey(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type OrderCompleted struct {
+		OrderID string
+	}
+
+	gotKey := []byte("not called")
+	rb.ReactTo("order.completed", func(key []byte, event OrderCompleted) error {
+		gotKey = key
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotKey != nil {
+		t.Errorf("got key %q, want nil", gotKey)
+	}
+}
+
+func TestWithDecoderScope(t *testing.T) {
+	rb := &rebound.Rebound{Decoder: rebound.JSONDecoder}
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	scoped := rebound.DecodeFunc(func(data []byte, v interface{}) error {
+		v.(*OrderEvent).OrderID = "scoped"
+		return nil
+	})
+
+	data := []byte(`{"OrderID":"123"}`)
+	rb.WithDecoderScope(scoped, func() {
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if err := rb.Dispatch("order.completed", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"scoped", "123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if f, ok := rb.Decoder.(rebound.DecodeFunc); !ok || reflect.ValueOf(f).Pointer() != reflect.ValueOf(rebound.JSONDecoder).Pointer() {
+		t.Errorf("got decoder %T, want %T restored", rb.Decoder, rebound.JSONDecoder)
+	}
+}
+
+func TestWithDecoderScope_decodeCache(t *testing.T) {
+	rb := rebound.New(rebound.WithDecodeCache(10))
+
+	var got []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = append(got, event.OrderID)
+		return nil
+	})
+
+	data := []byte(`{"OrderID":"json"}`)
+	if err := rb.Dispatch("order.completed", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rb.WithDecoderScope(rebound.DecodeFunc(func(data []byte, v interface{}) error {
+		v.(*OrderEvent).OrderID = "scoped"
+		return nil
+	}), func() {
+		if err := rb.Dispatch("order.completed", data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if err := rb.Dispatch("order.completed", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"json", "scoped", "json"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWithDecoderScope_panic(t *testing.T) {
+	rb := &rebound.Rebound{Decoder: rebound.JSONDecoder}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expect panic")
+			}
+		}()
+
+		rb.WithDecoderScope(rebound.DecodeFunc(json.Unmarshal), func() {
+			panic("boom")
+		})
+	}()
+
+	if f, ok := rb.Decoder.(rebound.DecodeFunc); !ok || reflect.ValueOf(f).Pointer() != reflect.ValueOf(rebound.JSONDecoder).Pointer() {
+		t.Errorf("got decoder %T, want %T restored", rb.Decoder, rebound.JSONDecoder)
+	}
+}
+
+func TestDispatch_errorSentinels(t *testing.T) {
+	handlerErr := errors.New("handler error")
+
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return handlerErr
+	})
+
+	rb.ReactToFunc("order.canceled", func(data []byte) error {
+		return handlerErr
+	})
+
+	testCases := map[string]struct {
+		eventName string
+		data      string
+		want      error
+	}{
+		"empty name":   {eventName: "", data: `{}`, want: rebound.ErrEmptyName},
+		"no handler":   {eventName: "order.shipped", data: `{}`, want: rebound.ErrNoHandler},
+		"decode":       {eventName: "order.completed", data: `{`, want: rebound.ErrDecode},
+		"handler":      {eventName: "order.completed", data: `{}`, want: rebound.ErrHandler},
+		"func handler": {eventName: "order.canceled", data: `{}`, want: rebound.ErrHandler},
+	}
+
+	sentinels := []error{rebound.ErrEmptyName, rebound.ErrNoHandler, rebound.ErrDecode, rebound.ErrHandler}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := rb.Dispatch(tc.eventName, []byte(tc.data))
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == tc.want; got != want {
+					t.Errorf("errors.Is(%v, %v) got %t, want %t", err, sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	handlerErr := errors.New("handler error")
+
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return handlerErr
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{}`))
+
+	var hErr rebound.HandlerError
+	if !errors.As(err, &hErr) {
+		t.Fatalf("got error %v, want %T", err, hErr)
+	}
+
+	if got, want := hErr.EventName, "order.completed"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("got error %v, want it to wrap %v", err, handlerErr)
+	}
+
+	if got, want := err.Error(), `rebound: handler of event "order.completed" failed: handler error`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWithSkipValidation(t *testing.T) {
+	rb := rebound.New(rebound.WithSkipValidation(true))
+
+	var got OrderEvent
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		got = event
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "123"; got.OrderID != want {
+		t.Errorf("got %q, want %q", got.OrderID, want)
+	}
+}
+
+func BenchmarkReactTo(b *testing.B) {
+	eventNames := make([]string, 1000)
+	for i := range eventNames {
+		eventNames[i] = "order.completed." + strconv.Itoa(i)
+	}
+
+	benchmarks := map[string][]rebound.Option{
+		"validated": nil,
+		"unchecked": {rebound.WithSkipValidation(true)},
+	}
+
+	for name, opts := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rb := rebound.New(opts...)
+				for _, eventName := range eventNames {
+					rb.ReactTo(eventName, func(ctx context.Context, event OrderEvent) error { return nil })
+				}
+			}
+		})
+	}
+}
+
+func TestWithUseNumber(t *testing.T) {
+	data := []byte(`{"ID":9007199254740993}`)
+
+	testCases := map[string]struct {
+		enabled bool
+		want    interface{}
+	}{
+		"enabled":  {enabled: true, want: json.Number("9007199254740993")},
+		"disabled": {enabled: false, want: float64(9007199254740992)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithUseNumber(tc.enabled))
+
+			var got interface{}
+			rb.ReactTo("order.completed", func(event map[string]interface{}) error {
+				got = event["ID"]
+				return nil
+			})
+
+			if err := rb.Dispatch("order.completed", data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %v (%T), want %v (%T)", got, got, tc.want, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithUseNumber_trailingData(t *testing.T) {
+	rb := rebound.New(rebound.WithUseNumber(true))
+	rb.ReactTo("order.completed", func(event map[string]interface{}) error {
+		return nil
+	})
+
+	for _, data := range []string{`{"ID":1} {"ID":2}`, `{"ID":1}}`, `{"ID":1}]`} {
+		err := rb.Dispatch("order.completed", []byte(data))
+		if !errors.Is(err, rebound.ErrDecode) {
+			t.Errorf("got error %v for %s, want %v", err, data, rebound.ErrDecode)
+		}
+	}
+
+	if err := rb.Dispatch("order.completed", []byte(`{"ID":1} `)); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+}
+
+func TestDispatch_handlerReturnsNoHandlerError(t *testing.T) {
+	rb := rebound.New(rebound.WithUnhandledTracking(), rebound.WithPanicOnUnhandled(false))
+
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		// the nested event has no handler
+		return rb.DispatchContext(ctx, "invoice.requested", []byte(`{}`))
+	})
+
+	ran, err := rb.TryDispatch(context.Background(), "order.completed", []byte(`{"OrderID":"123"}`))
+	if !ran {
+		t.Error("expect the handler to run")
+	}
+
+	if !errors.Is(err, rebound.ErrHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrHandler)
+	}
+
+	var hErr rebound.HandlerError
+	if !errors.As(err, &hErr) || hErr.EventName != "order.completed" {
+		t.Fatalf("got error %v, want a handler error of %q", err, "order.completed")
+	}
+
+	var noHandlerErr rebound.NoHandlerError
+	if !errors.As(hErr.Err, &noHandlerErr) || noHandlerErr.EventName != "invoice.requested" {
+		t.Errorf("got handler error %v, want a no handler error of %q", hErr.Err, "invoice.requested")
+	}
+
+	if got, want := rb.UnhandledEvents(), []string{"invoice.requested"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got unhandled events %v, want %v", got, want)
+	}
+}
+
+func TestDispatch_handlerReturnsNoHandlerErrorNoPanic(t *testing.T) {
+	rb := rebound.New(rebound.WithPanicOnUnhandled(true))
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return rebound.NoHandlerError{EventName: "invoice.requested"}
+	})
+
+	defer func() {
+		if v := recover(); v != nil {
+			t.Errorf("unexpected panic: %v", v)
+		}
+	}()
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if !errors.Is(err, rebound.ErrHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrHandler)
+	}
+}
+
+type orderService struct {
+	completed []string
+}
+
+func (s *orderService) HandleOrderCompleted(ctx context.Context, event OrderEvent) error {
+	s.completed = append(s.completed, event.OrderID)
+	return nil
+}
+
+type orderNotifier struct {
+	err error
+}
+
+func (n orderNotifier) HandleOrderCompleted(event OrderEvent) error {
+	return n.err
+}
+
+func TestReactTo_boundMethod(t *testing.T) {
+	svc := &orderService{}
+	notifyErr := errors.New("notify error")
+	notifier := orderNotifier{err: notifyErr}
+
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", svc.HandleOrderCompleted)
+	rb.ReactToWithPriority("order.completed", 1, notifier.HandleOrderCompleted)
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+	if !errors.Is(err, notifyErr) {
+		t.Errorf("got error %v, want %v", err, notifyErr)
+	}
+
+	if got, want := svc.completed, []string{"123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got completed %v, want %v", got, want)
+	}
+}
+
+func TestValidateHandler_methodExpression(t *testing.T) {
+	err := rebound.ValidateHandler((*orderService).HandleOrderCompleted)
+	if err == nil {
+		t.Fatal("expect error")
+	}
+
+	err = rebound.ValidateHandler(orderNotifier.HandleOrderCompleted)
+	if err == nil {
+		t.Fatal("expect error")
+	}
+}
+
+func TestWithAllowEmptyData(t *testing.T) {
+	testCases := map[string]struct {
+		opts    []rebound.Option
+		wantErr error
+	}{
+		"default": {
+			wantErr: rebound.ErrDecode,
+		},
+		"allow": {
+			opts: []rebound.Option{rebound.WithAllowEmptyData(true)},
+		},
+	}
+
+	dispatches := map[string]func(rb *rebound.Rebound) error{
+		"nil data": func(rb *rebound.Rebound) error {
+			return rb.Dispatch("order.completed", nil)
+		},
+		"empty reader": func(rb *rebound.Rebound) error {
+			return rb.DispatchReader("order.completed", strings.NewReader(""))
+		},
+	}
+
+	for name, tc := range testCases {
+		for dispatchName, dispatch := range dispatches {
+			t.Run(name+"/"+dispatchName, func(t *testing.T) {
+				rb := rebound.New(tc.opts...)
+
+				var called bool
+				rb.ReactTo("order.completed", func(event OrderEvent) error {
+					called = true
+					if event != (OrderEvent{}) {
+						t.Errorf("got event %v, want zero value", event)
+					}
+
+					return nil
+				})
+
+				err := dispatch(rb)
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("got error %v, want %v", err, tc.wantErr)
+				}
+
+				if want := tc.wantErr == nil; called != want {
+					t.Errorf("got called %t, want %t", called, want)
+				}
+			})
+		}
+	}
+}
+
+type domainError struct {
+	eventName string
+	err       error
+}
+
+func (e domainError) Error() string {
+	return "domain: " + e.eventName + ": " + e.err.Error()
+}
+
+func (e domainError) Unwrap() error {
+	return e.err
+}
+
+func TestWithErrorMapper(t *testing.T) {
+	var handled []error
+	rb := rebound.New(
+		rebound.WithErrorMapper(func(eventName string, err error) error {
+			if errors.Is(err, rebound.ErrNoHandler) {
+				return nil
+			}
+
+			return domainError{eventName: eventName, err: err}
+		}),
+		rebound.WithErrorHandler(func(eventName string, data []byte, err error) {
+			handled = append(handled, err)
+		}),
+	)
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return handlerErr
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{}`))
+
+	var domainErr domainError
+	if !errors.As(err, &domainErr) {
+		t.Fatalf("got error %v, want %T", err, domainErr)
+	}
+
+	if domainErr.eventName != "order.completed" {
+		t.Errorf("got event name %q, want %q", domainErr.eventName, "order.completed")
+	}
+
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("got error %v, want %v", err, handlerErr)
+	}
+
+	if err := rb.Dispatch("order.unknown", []byte(`{}`)); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+
+	if len(handled) != 1 || !errors.As(handled[0], &domainErr) {
+		t.Errorf("got handled errors %v, want the mapped handler error only", handled)
+	}
+}
+
+func TestReactTo_raw(t *testing.T) {
+	rb := rebound.New(rebound.WithDataPath("payload"))
+
+	data := []byte(`{"payload":{"OrderID":"123"},"signature":"abc"}`)
+	wantSum := sha256.Sum256(data)
+
+	var (
+		gotEvent OrderEvent
+		gotSum   [sha256.Size]byte
+		gotKey   []byte
+	)
+
+	rb.ReactTo("order.completed", func(key []byte, event OrderEvent, raw []byte) error {
+		gotEvent, gotSum, gotKey = event, sha256.Sum256(raw), key
+		return nil
+	})
+
+	testCases := map[string]struct {
+		dispatch func() error
+		wantKey  string
+	}{
+		"Dispatch": {
+			dispatch: func() error {
+				return rb.Dispatch("order.completed", data)
+			},
+		},
+		"DispatchReader": {
+			dispatch: func() error {
+				return rb.DispatchReader("order.completed", bytes.NewReader(data))
+			},
+		},
+		"DispatchKeyed": {
+			dispatch: func() error {
+				return rb.DispatchKeyed("order.completed", []byte("k1"), data)
+			},
+			wantKey: "k1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gotEvent, gotSum, gotKey = OrderEvent{}, [sha256.Size]byte{}, nil
+			if err := tc.dispatch(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want := (OrderEvent{OrderID: "123"}); gotEvent != want {
+				t.Errorf("got event %v, want %v", gotEvent, want)
+			}
+
+			if gotSum != wantSum {
+				t.Error("got raw data hash mismatch, want the hash of the original data")
+			}
+
+			if string(gotKey) != tc.wantKey {
+				t.Errorf("got key %q, want %q", gotKey, tc.wantKey)
+			}
+		})
+	}
+}
+
+func TestWithDecodeFallback(t *testing.T) {
+	var (
+		quarantined []string
+		gotErr      error
+	)
+
+	quarantineErr := errors.New("quarantined")
+	rb := rebound.New(rebound.WithDecodeFallback(func(eventName string, data []byte, decodeErr error) error {
+		quarantined = append(quarantined, eventName+" "+string(data))
+		gotErr = decodeErr
+		return quarantineErr
+	}))
+
+	var handled []string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		handled = append(handled, event.OrderID)
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := rb.DispatchReader("order.completed", strings.NewReader(`{"OrderID":2}`))
+	if !errors.Is(err, quarantineErr) {
+		t.Errorf("got error %v, want %v", err, quarantineErr)
+	}
+
+	if !errors.Is(gotErr, rebound.ErrDecode) {
+		t.Errorf("got decode error %v, want %v", gotErr, rebound.ErrDecode)
+	}
+
+	if want := []string{`order.completed {"OrderID":2}`}; !reflect.DeepEqual(quarantined, want) {
+		t.Errorf("got quarantined %v, want %v", quarantined, want)
+	}
+
+	if want := []string{"1"}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("got handled %v, want %v", handled, want)
+	}
+}
+
+func TestWith(t *testing.T) {
+	var got []string
+	handler := func(name string) func(event OrderEvent) error {
+		return func(event OrderEvent) error {
+			got = append(got, name)
+			return nil
+		}
+	}
+
+	rb := &rebound.Rebound{}
+	if chained := rb.With("order.completed", handler("completed")).
+		With("order.shipped", handler("shipped")).
+		With("order.cancelled", handler("cancelled")); chained != rb {
+		t.Fatal("expect the chain to return the receiver")
+	}
+
+	for _, eventName := range []string{"order.completed", "order.shipped", "order.cancelled"} {
+		if err := rb.Dispatch(eventName, []byte(`{}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if want := []string{"completed", "shipped", "cancelled"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
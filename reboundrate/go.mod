@@ -0,0 +1,10 @@
+module github.com/uudashr/rebound/reboundrate
+
+go 1.22.0
+
+require (
+	github.com/uudashr/rebound v0.0.0-00010101000000-000000000000
+	golang.org/x/time v0.8.0
+)
+
+replace github.com/uudashr/rebound => ../
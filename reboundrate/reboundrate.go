@@ -0,0 +1,48 @@
+// Package reboundrate provides a rebound middleware rate limiting the
+// dispatch of an event.
+//
+// It is a module of its own, so only its users depend on golang.org/x/time:
+//
+//	go get github.com/uudashr/rebound/reboundrate
+package reboundrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uudashr/rebound"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedError indicates that the event was rejected because its
+// dispatch rate exceeds the limit.
+type RateLimitedError struct {
+	EventName string
+}
+
+// Error returns the error message for RateLimitedError.
+func (e RateLimitedError) Error() string {
+	return fmt.Sprintf("reboundrate: event %q is rate limited", e.EventName)
+}
+
+// RateLimit returns a middleware allowing the given event to be dispatched at
+// most limit times per second, with bursts of at most burst events. An event
+// exceeding the rate is not dispatched, and a RateLimitedError is returned
+// instead. Other events pass through untouched.
+func RateLimit(eventName string, limit rate.Limit, burst int) rebound.Middleware {
+	limiter := rate.NewLimiter(limit, burst)
+
+	return func(next rebound.DispatchFunc) rebound.DispatchFunc {
+		return func(ctx context.Context, name string, data []byte) error {
+			if name != eventName {
+				return next(ctx, name, data)
+			}
+
+			if !limiter.Allow() {
+				return RateLimitedError{EventName: name}
+			}
+
+			return next(ctx, name, data)
+		}
+	}
+}
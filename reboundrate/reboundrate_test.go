@@ -0,0 +1,56 @@
+package reboundrate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+	"github.com/uudashr/rebound/reboundrate"
+	"golang.org/x/time/rate"
+)
+
+type OrderCompleted struct {
+	OrderID string
+}
+
+func TestRateLimit(t *testing.T) {
+	rb := rebound.New(rebound.WithMiddleware(
+		reboundrate.RateLimit("order.completed", rate.Every(time.Hour), 2),
+	))
+
+	var calls int
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		calls++
+		return nil
+	})
+
+	rb.ReactTo("order.canceled", func(event OrderCompleted) error { return nil })
+
+	for i := 0; i < 2; i++ {
+		if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`))
+
+	var limitedErr reboundrate.RateLimitedError
+	if !errors.As(err, &limitedErr) {
+		t.Fatalf("got %v, want RateLimitedError", err)
+	}
+
+	if got, want := limitedErr.EventName, "order.completed"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, want := calls, 2; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := rb.Dispatch("order.canceled", []byte(`{"OrderID":"123"}`)); err != nil {
+			t.Fatalf("unexpected error for other event: %v", err)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/uudashr/rebound/reboundschema
+
+go 1.22.0
+
+require (
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.3
+	github.com/uudashr/rebound v0.0.0-00010101000000-000000000000
+)
+
+require golang.org/x/text v0.14.0 // indirect
+
+replace github.com/uudashr/rebound => ../
@@ -0,0 +1,81 @@
+// Package reboundschema provides a rebound middleware validating the event
+// data against a JSON Schema before it is decoded.
+//
+// It is a module of its own, so only its users depend on the JSON Schema
+// implementation:
+//
+//	go get github.com/uudashr/rebound/reboundschema
+package reboundschema
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/uudashr/rebound"
+)
+
+// ValidationError indicates that the event data does not match the schema.
+type ValidationError struct {
+	EventName string
+	Err       error
+}
+
+// Error returns the error message for ValidationError.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("reboundschema: event %q data does not match the schema: %v", e.EventName, e.Err)
+}
+
+// Unwrap returns the underlying validation error.
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaValidate returns a middleware validating the data of the given event
+// against the JSON Schema. An event data failing the validation is not
+// dispatched, and a ValidationError is returned instead. Other events pass
+// through untouched.
+//
+// It panics if the schema cannot be compiled.
+func SchemaValidate(eventName string, schema []byte) rebound.Middleware {
+	sch, err := compile(eventName, schema)
+	if err != nil {
+		panic(fmt.Sprintf("reboundschema: invalid schema for event %q: %v", eventName, err))
+	}
+
+	return func(next rebound.DispatchFunc) rebound.DispatchFunc {
+		return func(ctx context.Context, name string, data []byte) error {
+			if name != eventName {
+				return next(ctx, name, data)
+			}
+
+			inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+			if err != nil {
+				return ValidationError{EventName: name, Err: err}
+			}
+
+			if err := sch.Validate(inst); err != nil {
+				return ValidationError{EventName: name, Err: err}
+			}
+
+			return next(ctx, name, data)
+		}
+	}
+}
+
+func compile(eventName string, schema []byte) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	url := "rebound://" + eventName + ".json"
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+
+	return c.Compile(url)
+}
@@ -0,0 +1,85 @@
+package reboundschema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+	"github.com/uudashr/rebound/reboundschema"
+)
+
+const orderCompletedSchema = `{
+	"type": "object",
+	"properties": {
+		"OrderID": {"type": "string", "minLength": 1},
+		"Amount": {"type": "integer", "minimum": 0}
+	},
+	"required": ["OrderID"]
+}`
+
+type OrderCompleted struct {
+	OrderID string
+	Amount  int
+}
+
+func TestSchemaValidate(t *testing.T) {
+	rb := rebound.New(rebound.WithMiddleware(
+		reboundschema.SchemaValidate("order.completed", []byte(orderCompletedSchema)),
+	))
+
+	var calls int
+	rb.ReactTo("order.completed", func(event OrderCompleted) error {
+		calls++
+		return nil
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123","Amount":100}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = rb.Dispatch("order.completed", []byte(`{"Amount":-1}`))
+
+	var validationErr reboundschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got %v, want ValidationError", err)
+	}
+
+	if got, want := validationErr.EventName, "order.completed"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got, want := calls, 1; got != want {
+		t.Errorf("got %d calls, want %d", got, want)
+	}
+}
+
+func TestSchemaValidate_otherEvents(t *testing.T) {
+	rb := rebound.New(rebound.WithMiddleware(
+		reboundschema.SchemaValidate("order.completed", []byte(orderCompletedSchema)),
+	))
+
+	var called bool
+	rb.ReactTo("order.canceled", func(event OrderCompleted) error {
+		called = true
+		return nil
+	})
+
+	if err := rb.Dispatch("order.canceled", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !called {
+		t.Error("expected handler to be called")
+	}
+}
+
+func TestSchemaValidate_invalidSchema(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	reboundschema.SchemaValidate("order.completed", []byte(`{"type": 1}`))
+}
@@ -0,0 +1,101 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// RecoverMode selects the dispatch paths recovering from panics (see
+// WithRecover).
+type RecoverMode int
+
+const (
+	// RecoverNone recovers from no panic. This is the default.
+	RecoverNone RecoverMode = 0
+
+	// RecoverSync recovers from the panics of the synchronous dispatch, such
+	// as Dispatch, DispatchContext or DispatchReader.
+	RecoverSync RecoverMode = 1
+
+	// RecoverAsync recovers from the panics of DispatchAsync, where a panic
+	// would otherwise crash the program from its goroutine.
+	RecoverAsync RecoverMode = 2
+
+	// RecoverAll recovers from the panics of all the dispatch paths.
+	RecoverAll = RecoverSync | RecoverAsync
+)
+
+// PanicError indicates that the dispatch of an event panicked, typically in
+// a handler. Stack holds the stack trace of the panic if captured (see
+// WithPanicStack).
+type PanicError struct {
+	EventName string
+	Value     interface{}
+	Stack     []byte
+}
+
+// Error returns the error message for PanicError.
+func (e PanicError) Error() string {
+	return fmt.Sprintf("rebound: dispatch of event %q panicked: %v", e.EventName, e.Value)
+}
+
+// routeRecover routes the event, recovering from a panic into a PanicError
+// when enabled for the dispatch path.
+func (r *Rebound) routeRecover(ctx context.Context, eventName string, p *payload) (ran bool, err error) {
+	if r.recovers(p) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = r.panicError(eventName, v)
+			}
+		}()
+	}
+
+	if len(r.middlewares) > 0 {
+		return r.routeMiddlewares(ctx, eventName, p)
+	}
+
+	return r.route(ctx, eventName, p)
+}
+
+// recovers reports whether the recovery is enabled for the dispatch path of
+// the payload.
+func (r *Rebound) recovers(p *payload) bool {
+	mode := RecoverSync
+	if p.async {
+		mode = RecoverAsync
+	}
+
+	return r.recoverMode&mode != 0
+}
+
+// callHandler calls the handler, wrapping its error in a HandlerError. When
+// recovering, a panic of the handler becomes a PanicError, so the remaining
+// handlers of the event still run.
+func (r *Rebound) callHandler(ctx context.Context, eventName string, h *handler, p *payload, event reflect.Value, recovering bool) (err error) {
+	if recovering {
+		defer func() {
+			if v := recover(); v != nil {
+				err = r.panicError(eventName, v)
+			}
+		}()
+	}
+
+	if err := h.call(ctx, p, event); err != nil {
+		return HandlerError{EventName: eventName, Err: err}
+	}
+
+	return nil
+}
+
+// panicError returns the PanicError for the recovered value v, capturing the
+// stack trace if enabled.
+func (r *Rebound) panicError(eventName string, v interface{}) PanicError {
+	err := PanicError{EventName: eventName, Value: v}
+	if r.panicStack {
+		err.Stack = debug.Stack()
+	}
+
+	return err
+}
@@ -0,0 +1,158 @@
+package rebound_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithRecover(t *testing.T) {
+	dispatchers := map[string]func(rb *rebound.Rebound) error{
+		"sync": func(rb *rebound.Rebound) error {
+			return rb.Dispatch("order.completed", []byte(`{}`))
+		},
+		"async": func(rb *rebound.Rebound) error {
+			return <-rb.DispatchAsync("order.completed", []byte(`{}`))
+		},
+	}
+
+	testCases := map[string]struct {
+		opts    []rebound.Option
+		recover map[string]bool
+	}{
+		"default": {
+			recover: map[string]bool{"sync": false, "async": false},
+		},
+		"sync": {
+			opts:    []rebound.Option{rebound.WithRecover(rebound.RecoverSync)},
+			recover: map[string]bool{"sync": true, "async": false},
+		},
+		"async": {
+			opts:    []rebound.Option{rebound.WithRecover(rebound.RecoverAsync)},
+			recover: map[string]bool{"sync": false, "async": true},
+		},
+		"all": {
+			opts:    []rebound.Option{rebound.WithRecover(rebound.RecoverAll)},
+			recover: map[string]bool{"sync": true, "async": true},
+		},
+	}
+
+	for name, tc := range testCases {
+		for path, dispatch := range dispatchers {
+			t.Run(name+"/"+path, func(t *testing.T) {
+				rb := rebound.New(tc.opts...)
+				rb.ReactTo("order.completed", func(event OrderEvent) error {
+					panic("boom")
+				})
+
+				wantRecover := tc.recover[path]
+				defer func() {
+					if v := recover(); (v != nil) == wantRecover {
+						t.Errorf("got panic %v, want recovered %t", v, wantRecover)
+					}
+				}()
+
+				err := dispatch(rb)
+
+				var panicErr rebound.PanicError
+				if !errors.As(err, &panicErr) {
+					t.Fatalf("got error %v, want %T", err, panicErr)
+				}
+
+				if got, want := panicErr.Value, "boom"; got != want {
+					t.Errorf("got panic value %v, want %v", got, want)
+				}
+
+				if got, want := panicErr.EventName, "order.completed"; got != want {
+					t.Errorf("got %q, want %q", got, want)
+				}
+
+			})
+		}
+	}
+}
+
+func TestWithRecover_asynchronous(t *testing.T) {
+	rb := rebound.New(rebound.WithSynchronous(false), rebound.WithRecover(rebound.RecoverAsync))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		panic("boom")
+	})
+
+	err := <-rb.DispatchAsync("order.completed", []byte(`{}`))
+
+	var panicErr rebound.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Errorf("got error %v, want %T", err, panicErr)
+	}
+}
+
+func TestWithRecover_multipleHandlers(t *testing.T) {
+	rb := rebound.New(rebound.WithRecover(rebound.RecoverSync))
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		panic("boom")
+	})
+
+	handlerErr := errors.New("handler error")
+	var called bool
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderEvent) error {
+		called = true
+		return handlerErr
+	})
+
+	err := rb.Dispatch("order.completed", []byte(`{}`))
+	if !called {
+		t.Error("expect the second handler to be called")
+	}
+
+	var panicErr rebound.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Errorf("got error %v, want %T", err, panicErr)
+	}
+
+	if got, want := panicErr.Value, "boom"; got != want {
+		t.Errorf("got panic value %v, want %v", got, want)
+	}
+
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("got error %v, want %v", err, handlerErr)
+	}
+}
+
+func TestWithPanicStack(t *testing.T) {
+	testCases := map[string]struct {
+		opts      []rebound.Option
+		wantStack bool
+	}{
+		"default": {},
+		"enabled": {
+			opts:      []rebound.Option{rebound.WithPanicStack(true)},
+			wantStack: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(append(tc.opts, rebound.WithRecover(rebound.RecoverSync))...)
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				panic("boom")
+			})
+
+			err := rb.Dispatch("order.completed", []byte(`{}`))
+
+			var panicErr rebound.PanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatalf("got error %v, want %T", err, panicErr)
+			}
+
+			if got := len(panicErr.Stack) > 0; got != tc.wantStack {
+				t.Errorf("got stack captured %t, want %t", got, tc.wantStack)
+			}
+
+			if tc.wantStack && !strings.Contains(string(panicErr.Stack), "TestWithPanicStack") {
+				t.Errorf("got stack %s, want the panicking handler", panicErr.Stack)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package rebound
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ReactToRegexp registers an event handler for the event names matching the
+// given regular expression. It panics if the regular expression already has
+// a handler.
+//
+// The regular expressions are used after the exact handlers and the patterns
+// registered using ReactToPattern, but before the ones registered using
+// ReactToRemaining. When multiple regular expressions match, the first
+// registered one is used.
+func (r *Rebound) ReactToRegexp(re *regexp.Regexp, fn EventHandler) {
+	if re == nil {
+		panic("rebound: re is nil")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	pattern := re.String()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ph := range r.reg.patterns {
+		if ph.re != nil && ph.pattern == pattern {
+			panic(fmt.Sprintf("rebound: regexp %q already has a handler", pattern))
+		}
+	}
+
+	h := newHandler(fn, 0)
+	r.checkEventType(pattern, h.eventType)
+
+	r.reg.patterns = append(r.reg.patterns, &patternHandler{
+		pattern: pattern,
+		h:       h,
+		re:      re,
+	})
+}
@@ -0,0 +1,67 @@
+package rebound_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestReactToRegexp(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	handle := func(name string) func(event OrderEvent) error {
+		return func(event OrderEvent) error {
+			got = append(got, name)
+			return nil
+		}
+	}
+
+	rb.ReactToRemaining(">", handle("remaining"))
+	rb.ReactToRegexp(regexp.MustCompile(`^(order|payment)\.(created|updated)$`), handle("created or updated"))
+	rb.ReactToRegexp(regexp.MustCompile(`\.(created|deleted)$`), handle("created or deleted"))
+	rb.ReactToPattern("order.*", handle("pattern"))
+	rb.ReactTo("payment.created", handle("exact"))
+
+	testCases := map[string]struct {
+		eventName string
+		want      string
+	}{
+		"exact over regexp":   {eventName: "payment.created", want: "exact"},
+		"pattern over regexp": {eventName: "order.created", want: "pattern"},
+		"first regexp":        {eventName: "payment.updated", want: "created or updated"},
+		"second regexp":       {eventName: "user.deleted", want: "created or deleted"},
+		"regexp overlap":      {eventName: "invoice.created", want: "created or deleted"},
+		"remaining":           {eventName: "user.updated", want: "remaining"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			if err := rb.Dispatch(tc.eventName, []byte(`{}`)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != 1 || got[0] != tc.want {
+				t.Errorf("got %v, want [%s]", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := rb.Explain("user.deleted"), (rebound.Routing{Key: `\.(created|deleted)$`, Kind: rebound.MatchRegexp, EventType: rb.Explain("order.created").EventType}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReactToRegexp_duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb := &rebound.Rebound{}
+	rb.ReactToRegexp(regexp.MustCompile(`^order\.`), func(event OrderEvent) error { return nil })
+	rb.ReactToRegexp(regexp.MustCompile(`^order\.`), func(event OrderEvent) error { return nil })
+}
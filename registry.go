@@ -0,0 +1,114 @@
+package rebound
+
+import "reflect"
+
+// registry holds the registered handlers, migrations and types.
+type registry struct {
+	handlers   map[string][]*handler
+	funcs      map[string]func(data []byte) error
+	patterns   []*patternHandler
+	migrations map[string]map[int]func(old []byte) ([]byte, error)
+	types      map[string]reflect.Type
+	declared   map[string]bool
+	aliases    map[string]string
+}
+
+// clone returns a copy of the registry. The handler slices are never
+// modified in place, so they are shared.
+func (reg *registry) clone() registry {
+	cp := registry{
+		handlers:   make(map[string][]*handler, len(reg.handlers)),
+		funcs:      make(map[string]func(data []byte) error, len(reg.funcs)),
+		patterns:   append([]*patternHandler(nil), reg.patterns...),
+		migrations: make(map[string]map[int]func(old []byte) ([]byte, error), len(reg.migrations)),
+		types:      make(map[string]reflect.Type, len(reg.types)),
+		declared:   make(map[string]bool, len(reg.declared)),
+		aliases:    make(map[string]string, len(reg.aliases)),
+	}
+
+	for eventName, hs := range reg.handlers {
+		cp.handlers[eventName] = hs
+	}
+
+	for eventName, fn := range reg.funcs {
+		cp.funcs[eventName] = fn
+	}
+
+	for eventName, ms := range reg.migrations {
+		cpms := make(map[int]func(old []byte) ([]byte, error), len(ms))
+		for version, fn := range ms {
+			cpms[version] = fn
+		}
+
+		cp.migrations[eventName] = cpms
+	}
+
+	for discriminator, typ := range reg.types {
+		cp.types[discriminator] = typ
+	}
+
+	for eventName := range reg.declared {
+		cp.declared[eventName] = true
+	}
+
+	for alias, eventName := range reg.aliases {
+		cp.aliases[alias] = eventName
+	}
+
+	return cp
+}
+
+// Registry is an opaque copy of the handlers, migrations and types registered
+// to a Rebound, taken using Snapshot.
+type Registry struct {
+	reg registry
+}
+
+// Snapshot returns a copy of the registered handlers, migrations and types,
+// which can later be restored using Restore. Registrations made after the
+// snapshot do not affect it.
+func (r *Rebound) Snapshot() Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return Registry{reg: r.reg.clone()}
+}
+
+// Restore atomically replaces the registered handlers, migrations and types
+// with the ones in reg. Dispatches running concurrently use either the previous or the
+// restored handlers, so no event is dropped during the swap.
+func (r *Rebound) Restore(reg Registry) {
+	cp := reg.reg.clone()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reg = cp
+}
+
+// lookup returns the handlers of the event, resolving its alias and then the
+// patterns when there is no exact handler, along with the event migrations.
+// The handlers are acquired as in flight, to be released once called.
+func (r *Rebound) lookup(eventName string) (fn func(data []byte) error, hs []*handler, ms map[int]func(old []byte) ([]byte, error)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if target, ok := r.reg.aliases[eventName]; ok && !r.hasHandler(eventName) {
+		eventName = target
+	}
+
+	ms = r.reg.migrations[eventName]
+	if fn = r.reg.funcs[eventName]; fn != nil {
+		return fn, nil, ms
+	}
+
+	hs = r.reg.handlers[eventName]
+	if len(hs) == 0 {
+		if ph := r.matchPattern(eventName); ph != nil {
+			hs = []*handler{ph.h}
+		}
+	}
+
+	acquireHandlers(hs)
+	return nil, hs, ms
+}
@@ -0,0 +1,87 @@
+package rebound_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got string
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		got = "original"
+		return nil
+	})
+
+	snapshot := rb.Snapshot()
+
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderEvent) error {
+		got = "added"
+		return nil
+	})
+
+	rb.ReactTo("order.canceled", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "added"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	rb.Restore(snapshot)
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "original"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var noHandlerErr rebound.NoHandlerError
+	if err := rb.Dispatch("order.canceled", []byte(`{}`)); !errors.As(err, &noHandlerErr) {
+		t.Errorf("got %v, want NoHandlerError", err)
+	}
+
+	// the snapshot is not affected by registrations after restore
+	rb.ReactTo("order.canceled", func(event OrderEvent) error { return nil })
+	rb.Restore(snapshot)
+
+	if err := rb.Dispatch("order.canceled", []byte(`{}`)); !errors.As(err, &noHandlerErr) {
+		t.Errorf("got %v, want NoHandlerError", err)
+	}
+}
+
+func TestRestore_concurrentDispatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	snapshot := rb.Snapshot()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				if err := rb.Dispatch("order.completed", []byte(`{}`)); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		rb.Restore(snapshot)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,49 @@
+package rebound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type replyFuncKey struct{}
+
+// OnReply registers a streaming reply handler for a given event name, which
+// may send zero or more replies of type R to the DispatchReply of an event of
+// type T, such as for a NATS-style request with streaming replies. Each reply
+// is encoded to JSON and passed to the reply callback of DispatchReply, and
+// the error of the callback is returned to the handler.
+// It panics if the event already has a handler.
+func OnReply[T any, R any](r *Rebound, eventName string, fn func(event T, reply func(R) error) error) {
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.ReactTo(eventName, func(ctx context.Context, event T) error {
+		callback, _ := ctx.Value(replyFuncKey{}).(func([]byte) error)
+		return fn(event, func(reply R) error {
+			if callback == nil {
+				return fmt.Errorf("rebound: event %q is not dispatched for replies", eventName)
+			}
+
+			data, err := json.Marshal(reply)
+			if err != nil {
+				return fmt.Errorf("rebound: failed to encode event %q reply: %w", eventName, err)
+			}
+
+			return callback(data)
+		})
+	})
+}
+
+// DispatchReply handles an event by its name and associated data, passing
+// reply to the handler registered using OnReply, which calls it with each of
+// its encoded replies.
+func (r *Rebound) DispatchReply(eventName string, data []byte, reply func([]byte) error) error {
+	if reply == nil {
+		panic("rebound: reply is nil")
+	}
+
+	ctx := context.WithValue(context.Background(), replyFuncKey{}, reply)
+	return r.DispatchContext(ctx, eventName, data)
+}
@@ -0,0 +1,85 @@
+package rebound_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type OrderStatus struct {
+	OrderID string
+	Status  string
+}
+
+func TestDispatchReply(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rebound.OnReply(rb, "order.track", func(event OrderEvent, reply func(OrderStatus) error) error {
+		for _, status := range []string{"packed", "shipped"} {
+			if err := reply(OrderStatus{OrderID: event.OrderID, Status: status}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	var replies []string
+	err := rb.DispatchReply("order.track", []byte(`{"OrderID":"123"}`), func(data []byte) error {
+		replies = append(replies, string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`{"OrderID":"123","Status":"packed"}`,
+		`{"OrderID":"123","Status":"shipped"}`,
+	}
+
+	if !reflect.DeepEqual(replies, want) {
+		t.Errorf("got replies %v, want %v", replies, want)
+	}
+}
+
+func TestDispatchReply_callbackError(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var sent int
+	rebound.OnReply(rb, "order.track", func(event OrderEvent, reply func(OrderStatus) error) error {
+		for i := 0; i < 2; i++ {
+			if err := reply(OrderStatus{OrderID: event.OrderID}); err != nil {
+				return err
+			}
+
+			sent++
+		}
+
+		return nil
+	})
+
+	closedErr := errors.New("connection closed")
+	err := rb.DispatchReply("order.track", []byte(`{}`), func(data []byte) error {
+		return closedErr
+	})
+	if !errors.Is(err, closedErr) {
+		t.Errorf("got error %v, want %v", err, closedErr)
+	}
+
+	if sent != 0 {
+		t.Errorf("got %d replies sent, want 0", sent)
+	}
+}
+
+func TestOnReply_noCallback(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rebound.OnReply(rb, "order.track", func(event OrderEvent, reply func(OrderStatus) error) error {
+		return reply(OrderStatus{})
+	})
+
+	if err := rb.Dispatch("order.track", []byte(`{}`)); !errors.Is(err, rebound.ErrHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrHandler)
+	}
+}
@@ -0,0 +1,60 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type replyKey struct{}
+
+// replySlot receives the reply of a request handler.
+type replySlot struct {
+	eventType reflect.Type
+	reply     interface{}
+	replied   bool
+}
+
+// OnRequest registers a request handler for a given event name, replying with
+// a value of type R to the Ask of an event of type T.
+// It panics if the event already has a handler.
+func OnRequest[T any, R any](r *Rebound, eventName string, fn func(event T) (R, error)) {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+
+	r.ReactTo(eventName, func(ctx context.Context, event T) error {
+		slot, _ := ctx.Value(replyKey{}).(*replySlot)
+		if slot != nil && slot.eventType != eventType {
+			return fmt.Errorf("rebound: event %q request should be %v (got: %v)", eventName, eventType, slot.eventType)
+		}
+
+		reply, err := fn(event)
+		if slot != nil {
+			slot.reply, slot.replied = reply, true
+		}
+
+		return err
+	})
+}
+
+// Ask dispatches an event of type T to the request handler registered using
+// OnRequest, and returns its reply of type R.
+func Ask[T any, R any](r *Rebound, eventName string, data []byte) (R, error) {
+	var zero R
+
+	slot := &replySlot{eventType: reflect.TypeOf((*T)(nil)).Elem()}
+	ctx := context.WithValue(context.Background(), replyKey{}, slot)
+	if err := r.DispatchContext(ctx, eventName, data); err != nil {
+		return zero, err
+	}
+
+	if !slot.replied {
+		return zero, fmt.Errorf("rebound: event %q handler does not reply", eventName)
+	}
+
+	reply, ok := slot.reply.(R)
+	if !ok {
+		return zero, fmt.Errorf("rebound: event %q reply should be %v (got: %T)", eventName, reflect.TypeOf((*R)(nil)).Elem(), slot.reply)
+	}
+
+	return reply, nil
+}
@@ -0,0 +1,70 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type GetOrder struct {
+	OrderID string
+}
+
+type Order struct {
+	OrderID string
+	Status  string
+}
+
+func TestAsk(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	rebound.OnRequest(rb, "order.get", func(req GetOrder) (Order, error) {
+		return Order{OrderID: req.OrderID, Status: "completed"}, nil
+	})
+
+	got, err := rebound.Ask[GetOrder, Order](rb, "order.get", []byte(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (Order{OrderID: "123", Status: "completed"}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestAsk_handlerError(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	notFoundErr := errors.New("not found")
+	rebound.OnRequest(rb, "order.get", func(req GetOrder) (Order, error) {
+		return Order{}, notFoundErr
+	})
+
+	_, err := rebound.Ask[GetOrder, Order](rb, "order.get", []byte(`{"OrderID":"123"}`))
+	if got, want := err, notFoundErr; !errors.Is(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAsk_mismatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	rebound.OnRequest(rb, "order.get", func(req GetOrder) (Order, error) {
+		return Order{OrderID: req.OrderID}, nil
+	})
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	if _, err := rebound.Ask[GetOrder, string](rb, "order.get", []byte(`{}`)); err == nil {
+		t.Error("expected error for reply type mismatch")
+	}
+
+	if _, err := rebound.Ask[OrderEvent, Order](rb, "order.get", []byte(`{}`)); err == nil {
+		t.Error("expected error for request type mismatch")
+	}
+
+	if _, err := rebound.Ask[OrderEvent, Order](rb, "order.completed", []byte(`{}`)); err == nil {
+		t.Error("expected error for non-request handler")
+	}
+}
@@ -0,0 +1,50 @@
+package rebound
+
+import (
+	"context"
+	"reflect"
+)
+
+type eventSlotKey struct{}
+
+// eventSlot receives the decoded event of a dispatch.
+type eventSlot struct {
+	event interface{}
+	set   bool
+}
+
+// DispatchAndReturn handles an event by its name and associated data, and
+// returns the decoded event passed to the handlers, along with the error.
+// When the event has multiple handlers, they share the returned event.
+//
+// The event is nil when the data fails to decode, or when the handler is
+// registered using ReactToFunc. For a handler registered using ReactToEach,
+// the event is a []interface{} of the elements.
+func (r *Rebound) DispatchAndReturn(eventName string, data []byte) (event interface{}, err error) {
+	slot := &eventSlot{}
+	ctx := context.WithValue(context.Background(), eventSlotKey{}, slot)
+	err = r.DispatchContext(ctx, eventName, data)
+	return slot.event, err
+}
+
+// returnEvents stores the decoded events in the event slot of ctx, if any and
+// not already set by an outer dispatch.
+func returnEvents(ctx context.Context, each bool, events []reflect.Value) {
+	slot, _ := ctx.Value(eventSlotKey{}).(*eventSlot)
+	if slot == nil || slot.set {
+		return
+	}
+
+	slot.set = true
+	if !each {
+		slot.event = events[0].Interface()
+		return
+	}
+
+	elems := make([]interface{}, len(events))
+	for i, event := range events {
+		elems[i] = event.Interface()
+	}
+
+	slot.event = elems
+}
@@ -0,0 +1,71 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDispatchAndReturn(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	handlerErr := errors.New("handler error")
+	rb.ReactTo("order.completed", func(ctx context.Context, event OrderEvent) error {
+		// a nested dispatch does not replace the returned event
+		rb.DispatchContext(ctx, "order.archived", []byte(`{"OrderID":"nested"}`))
+		return handlerErr
+	})
+
+	rb.ReactToWithPriority("order.completed", 1, func(event OrderEvent) error {
+		return nil
+	})
+
+	rb.ReactTo("order.archived", func(event OrderEvent) error {
+		return nil
+	})
+
+	event, err := rb.DispatchAndReturn("order.completed", []byte(`{"OrderID":"123"}`))
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("got error %v, want %v", err, handlerErr)
+	}
+
+	if want := (OrderEvent{OrderID: "123"}); event != want {
+		t.Errorf("got event %v, want %v", event, want)
+	}
+}
+
+func TestDispatchAndReturn_each(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactToEach("order.completed", func(event OrderEvent) error {
+		return nil
+	})
+
+	event, err := rb.DispatchAndReturn("order.completed", []byte(`[{"OrderID":"1"},{"OrderID":"2"}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []interface{}{OrderEvent{OrderID: "1"}, OrderEvent{OrderID: "2"}}
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("got event %v, want %v", event, want)
+	}
+}
+
+func TestDispatchAndReturn_decodeError(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		return nil
+	})
+
+	event, err := rb.DispatchAndReturn("order.completed", []byte(`{`))
+	if !errors.Is(err, rebound.ErrDecode) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrDecode)
+	}
+
+	if event != nil {
+		t.Errorf("got event %v, want nil", event)
+	}
+}
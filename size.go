@@ -0,0 +1,32 @@
+package rebound
+
+import "io"
+
+// countingReader counts the bytes read from its reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+// observeSize prepares the payload to report its size to the size observer,
+// counting the bytes read when dispatching from a reader. The returned
+// function reports the size once the dispatch is done.
+func (r *Rebound) observeSize(eventName string, p *payload) func() {
+	if p.rd == nil {
+		return func() {
+			r.sizeObserver(eventName, len(p.orig))
+		}
+	}
+
+	c := &countingReader{r: p.rd}
+	p.rd = c
+	return func() {
+		r.sizeObserver(eventName, c.n)
+	}
+}
@@ -0,0 +1,59 @@
+package rebound_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestWithSizeObserver(t *testing.T) {
+	data := `{"OrderID":"123"}`
+
+	testCases := map[string]struct {
+		dispatch func(rb *rebound.Rebound) error
+		want     int
+	}{
+		"Dispatch": {
+			dispatch: func(rb *rebound.Rebound) error {
+				return rb.Dispatch("order.completed", []byte(data))
+			},
+			want: len(data),
+		},
+		"DispatchReader": {
+			dispatch: func(rb *rebound.Rebound) error {
+				return rb.DispatchReader("order.completed", strings.NewReader(data+"\n"))
+			},
+			want: len(data) + 1,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				gotName string
+				gotSize int
+			)
+
+			rb := rebound.New(rebound.WithSizeObserver(func(eventName string, bytes int) {
+				gotName, gotSize = eventName, bytes
+			}))
+
+			rb.ReactTo("order.completed", func(event OrderEvent) error {
+				return nil
+			})
+
+			if err := tc.dispatch(rb); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gotName != "order.completed" {
+				t.Errorf("got event name %q, want %q", gotName, "order.completed")
+			}
+
+			if gotSize != tc.want {
+				t.Errorf("got size %d, want %d", gotSize, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package rebound
+
+import (
+	"context"
+	"fmt"
+)
+
+// SwapHandler replaces the handlers of a given event name with an event
+// handler, without dropping any event. The events dispatched before the swap
+// are still handled by the previous handlers, and SwapHandler returns once
+// they are done. The events dispatched after the swap are handled by the new
+// handler, which waits for the previous handlers to be done, so the previous
+// and the new handlers never run at the same time.
+// SwapHandler must not be called by a handler of the event, which would wait
+// for itself. It panics if the event has a handler registered using
+// ReactToFunc.
+func (r *Rebound) SwapHandler(eventName string, fn EventHandler) {
+	if eventName == "" {
+		panic("rebound: event name is empty")
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	h := newHandler(fn, 0)
+	ready := make(chan struct{})
+	h.ready = ready
+
+	r.mu.Lock()
+	if _, exists := r.reg.funcs[eventName]; exists {
+		r.mu.Unlock()
+		panic(fmt.Sprintf("rebound: event %q has a raw data handler", eventName))
+	}
+
+	prev := r.reg.handlers[eventName]
+	delete(r.reg.handlers, eventName)
+	r.addHandler(eventName, h)
+	r.mu.Unlock()
+
+	// the previous handlers are no longer looked up, so their in-flight
+	// count only goes down
+	for _, ph := range prev {
+		ph.inflight.Wait()
+	}
+
+	close(ready)
+}
+
+// waitReady waits for the handler to be ready to be called (see
+// SwapHandler), or ctx to be done.
+func (h *handler) waitReady(ctx context.Context) error {
+	if h.ready == nil {
+		return nil
+	}
+
+	select {
+	case <-h.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquireHandlers marks the handlers as in flight, until released. It
+// requires r.mu to be held, so SwapHandler waits for all the dispatches that
+// looked the handlers up.
+func acquireHandlers(hs []*handler) {
+	for _, h := range hs {
+		h.inflight.Add(1)
+	}
+}
+
+// releaseHandlers marks the handlers acquired using acquireHandlers as no
+// longer in flight.
+func releaseHandlers(hs []*handler) {
+	for _, h := range hs {
+		h.inflight.Done()
+	}
+}
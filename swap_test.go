@@ -0,0 +1,82 @@
+package rebound_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestSwapHandler(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		got = append(got, s)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		close(started)
+		<-release
+		record("old:" + event.OrderID)
+		return nil
+	})
+
+	oldDone := make(chan error, 1)
+	go func() {
+		oldDone <- rb.Dispatch("order.completed", []byte(`{"OrderID":"1"}`))
+	}()
+
+	<-started
+
+	swapped := make(chan struct{})
+	go func() {
+		rb.SwapHandler("order.completed", func(event OrderEvent) error {
+			record("new:" + event.OrderID)
+			return nil
+		})
+		close(swapped)
+	}()
+
+	select {
+	case <-swapped:
+		t.Fatal("expect the swap to wait for the in-flight handler")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// the dispatches after the swap wait for the in-flight old handler
+	newDone := make(chan error, 1)
+	go func() {
+		newDone <- rb.Dispatch("order.completed", []byte(`{"OrderID":"2"}`))
+	}()
+
+	select {
+	case <-newDone:
+		t.Fatal("expect the new handler to wait for the in-flight handler")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	<-swapped
+
+	for _, errc := range []<-chan error{oldDone, newDone} {
+		if err := <-errc; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if want := []string{"old:1", "new:2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+package rebound
+
+import "reflect"
+
+// Tap registers a function observing every decoded event, along with its
+// name, before the handlers are called, such as for a debugging console.
+// Multiple taps may be registered, and they are called in registration order.
+//
+// The events handled by a handler registered using ReactToFunc are not
+// decoded, so they are not tapped.
+func (r *Rebound) Tap(fn func(eventName string, event interface{})) {
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// appending never modifies the taps seen by the running dispatches, so
+	// they can call them unlocked
+	r.taps = append(r.taps, fn)
+}
+
+// tapEvents calls the taps with the decoded events.
+func (r *Rebound) tapEvents(eventName string, events []reflect.Value) {
+	r.mu.RLock()
+	taps := r.taps
+	r.mu.RUnlock()
+
+	for _, event := range events {
+		for _, fn := range taps {
+			fn(eventName, event.Interface())
+		}
+	}
+}
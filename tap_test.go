@@ -0,0 +1,67 @@
+package rebound_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestTap(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	type tapped struct {
+		eventName string
+		event     interface{}
+	}
+
+	var first, second []tapped
+	rb.Tap(func(eventName string, event interface{}) {
+		first = append(first, tapped{eventName, event})
+	})
+
+	rb.Tap(func(eventName string, event interface{}) {
+		second = append(second, tapped{eventName, event})
+	})
+
+	var handled int
+	rb.ReactTo("order.completed", func(event OrderEvent) error {
+		handled++
+		return nil
+	})
+
+	rb.ReactTo("invoice.issued", func(event InvoiceIssued) error {
+		handled++
+		return nil
+	})
+
+	if err := rb.Dispatch("order.completed", []byte(`{"OrderID":"123"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := rb.Dispatch("invoice.issued", []byte(`{"InvoiceID":"456"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a decode failure is not tapped
+	if err := rb.Dispatch("invoice.issued", []byte(`{`)); err == nil {
+		t.Fatal("expect error")
+	}
+
+	want := []tapped{
+		{"order.completed", OrderEvent{OrderID: "123"}},
+		{"invoice.issued", InvoiceIssued{InvoiceID: "456"}},
+	}
+
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("got first tap %v, want %v", first, want)
+	}
+
+	if !reflect.DeepEqual(second, want) {
+		t.Errorf("got second tap %v, want %v", second, want)
+	}
+
+	if got, want := handled, 2; got != want {
+		t.Errorf("got %d handled, want %d", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package rebound
+
+import (
+	"context"
+	"sync"
+)
+
+// Node is a dispatch of the dispatch tree (see WithDispatchTree), with the
+// dispatches made by its handlers as children. The root node has no event
+// name, and the top-level dispatches as children.
+type Node struct {
+	EventName string
+	Err       error
+	Children  []Node
+}
+
+// dispatchTree records the dispatches, nested by their context.
+type dispatchTree struct {
+	mu   sync.Mutex
+	root treeNode
+}
+
+type treeNode struct {
+	tree      *dispatchTree
+	eventName string
+	err       error
+	children  []*treeNode
+}
+
+type treeNodeKey struct{}
+
+// enter records the dispatch of the event as a child of the dispatch found in
+// ctx, and returns the context of the new dispatch.
+func (t *dispatchTree) enter(ctx context.Context, eventName string) (context.Context, *treeNode) {
+	parent, ok := ctx.Value(treeNodeKey{}).(*treeNode)
+	if !ok || parent.tree != t {
+		parent = &t.root
+	}
+
+	n := &treeNode{tree: t, eventName: eventName}
+
+	t.mu.Lock()
+	parent.children = append(parent.children, n)
+	t.mu.Unlock()
+
+	return context.WithValue(ctx, treeNodeKey{}, n), n
+}
+
+// leave records the result of the dispatch.
+func (t *dispatchTree) leave(n *treeNode, err error) {
+	t.mu.Lock()
+	n.err = err
+	t.mu.Unlock()
+}
+
+func (t *dispatchTree) snapshot() Node {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.root.node()
+}
+
+// node returns a copy of n. The caller must hold the tree lock.
+func (n *treeNode) node() Node {
+	node := Node{EventName: n.eventName, Err: n.err}
+	for _, child := range n.children {
+		node.Children = append(node.Children, child.node())
+	}
+
+	return node
+}
+
+// DispatchTree returns the dispatches recorded so far, when enabled (see
+// WithDispatchTree). A dispatch made by a handler, passing the context it
+// receives to DispatchContext, is a child of the dispatch calling the
+// handler.
+func (r *Rebound) DispatchTree() Node {
+	if r.tree == nil {
+		return Node{}
+	}
+
+	return r.tree.snapshot()
+}
@@ -0,0 +1,74 @@
+package rebound_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestDispatchTree(t *testing.T) {
+	rb := rebound.New(rebound.WithDispatchTree())
+
+	rb.ReactTo("order.placed", func(ctx context.Context, event OrderEvent) error {
+		if err := rb.DispatchContext(ctx, "payment.requested", []byte(`{}`)); err != nil {
+			return err
+		}
+
+		return rb.DispatchContext(ctx, "inventory.reserved", []byte(`{}`))
+	})
+
+	rb.ReactTo("payment.requested", func(ctx context.Context, event OrderEvent) error {
+		return rb.DispatchContext(ctx, "payment.captured", []byte(`{}`))
+	})
+
+	rb.ReactTo("payment.captured", func(event OrderEvent) error { return nil })
+	rb.ReactTo("inventory.reserved", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.placed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := rb.Dispatch("order.canceled", []byte(`{}`))
+
+	want := rebound.Node{
+		Children: []rebound.Node{
+			{
+				EventName: "order.placed",
+				Children: []rebound.Node{
+					{
+						EventName: "payment.requested",
+						Children: []rebound.Node{
+							{EventName: "payment.captured"},
+						},
+					},
+					{EventName: "inventory.reserved"},
+				},
+			},
+			{EventName: "order.canceled", Err: err},
+		},
+	}
+
+	if got := rb.DispatchTree(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+}
+
+func TestDispatchTree_disabled(t *testing.T) {
+	rb := &rebound.Rebound{}
+	rb.ReactTo("order.placed", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.placed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := rb.DispatchTree(); !reflect.DeepEqual(got, rebound.Node{}) {
+		t.Errorf("got %+v, want empty tree", got)
+	}
+}
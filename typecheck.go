@@ -0,0 +1,31 @@
+package rebound
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeChecker is implemented by the decoders able to tell, without data,
+// whether they can decode into a type.
+type TypeChecker interface {
+	// CheckType returns an error if the decoder cannot decode into a value
+	// of type t.
+	CheckType(t reflect.Type) error
+}
+
+// checkEventType panics if the decoder cannot decode the event type, when the
+// type check is enabled (see WithTypeCheck).
+func (r *Rebound) checkEventType(eventName string, eventType reflect.Type) {
+	if !r.typeCheck || eventType.Kind() == reflect.Interface {
+		return
+	}
+
+	tc, ok := r.decoder().(TypeChecker)
+	if !ok {
+		return
+	}
+
+	if err := tc.CheckType(eventType); err != nil {
+		panic(fmt.Sprintf("rebound: decoder cannot decode event %q into %v: %v", eventName, eventType, err))
+	}
+}
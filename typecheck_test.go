@@ -0,0 +1,99 @@
+package rebound_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type protoMessage interface {
+	ProtoMessage()
+}
+
+// protoDecoder mimics a protobuf decoder, only able to decode messages.
+type protoDecoder struct{}
+
+func (protoDecoder) Decode(data []byte, v interface{}) error {
+	if _, ok := v.(protoMessage); !ok {
+		return fmt.Errorf("%T is not a proto message", v)
+	}
+
+	return nil
+}
+
+func (protoDecoder) CheckType(t reflect.Type) error {
+	if !reflect.PointerTo(t).Implements(reflect.TypeOf((*protoMessage)(nil)).Elem()) {
+		return fmt.Errorf("%v is not a proto message", t)
+	}
+
+	return nil
+}
+
+type OrderPlacedProto struct {
+	OrderID string
+}
+
+func (*OrderPlacedProto) ProtoMessage() {}
+
+func TestWithTypeCheck(t *testing.T) {
+	testCases := map[string]struct {
+		decoder   rebound.Decoder
+		fn        rebound.EventHandler
+		wantPanic bool
+	}{
+		"json": {
+			decoder: rebound.JSONDecoder,
+			fn:      func(event OrderEvent) error { return nil },
+		},
+		"proto message": {
+			decoder: protoDecoder{},
+			fn:      func(event OrderPlacedProto) error { return nil },
+		},
+		"not a proto message": {
+			decoder:   protoDecoder{},
+			fn:        func(event OrderEvent) error { return nil },
+			wantPanic: true,
+		},
+		"decoder without type check": {
+			decoder: rebound.DecodeFunc(func(data []byte, v interface{}) error { return nil }),
+			fn:      func(event OrderEvent) error { return nil },
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if got := recover() != nil; got != tc.wantPanic {
+					t.Errorf("got panic %t, want %t", got, tc.wantPanic)
+				}
+			}()
+
+			rb := rebound.New(rebound.WithTypeCheck(true))
+			rb.Decoder = tc.decoder
+			rb.ReactTo("order.placed", tc.fn)
+		})
+	}
+}
+
+func TestWithTypeCheck_disabled(t *testing.T) {
+	rb := &rebound.Rebound{Decoder: protoDecoder{}}
+	rb.ReactTo("order.placed", func(event OrderEvent) error { return nil })
+
+	if err := rb.Dispatch("order.placed", []byte(`{}`)); err == nil {
+		t.Error("expect error")
+	}
+}
+
+func TestWithTypeCheck_pattern(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expect panic")
+		}
+	}()
+
+	rb := rebound.New(rebound.WithTypeCheck(true))
+	rb.Decoder = protoDecoder{}
+	rb.ReactToPattern("order.*", func(event OrderEvent) error { return nil })
+}
@@ -0,0 +1,33 @@
+package rebound
+
+// OnNamed registers a typed event handler for a given event name.
+// It panics if the event already has a handler.
+//
+// OnNamed is ReactTo with the handler form checked by the compiler, for when
+// the event name is not derived from the event type, such as when the name
+// used on the transport differs from the Go type name.
+func OnNamed[T any](r *Rebound, eventName string, fn func(event T) error) {
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.ReactTo(eventName, fn)
+}
+
+// ReactToProjection registers an event handler for a given event name, which
+// gets a projection of the event, such as a subset of its fields. The event
+// data is decoded into the event type T, then projected to P using project.
+// It panics if the event already has a handler.
+func ReactToProjection[T any, P any](r *Rebound, eventName string, project func(event T) P, fn func(projection P) error) {
+	if project == nil {
+		panic("rebound: project is nil")
+	}
+
+	if fn == nil {
+		panic("rebound: fn is nil")
+	}
+
+	r.ReactTo(eventName, func(event T) error {
+		return fn(project(event))
+	})
+}
@@ -0,0 +1,83 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestOnNamed(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got OrderEvent
+	rebound.OnNamed(rb, "orders.v1.completed", func(event OrderEvent) error {
+		got = event
+		return nil
+	})
+
+	err := rb.Dispatch("orders.v1.completed", []byte(`{"OrderID":"123"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (OrderEvent{OrderID: "123"}); got != want {
+		t.Errorf("got event %v, want %v", got, want)
+	}
+}
+
+func TestOnNamed_typeMismatch(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var called bool
+	rebound.OnNamed(rb, "orders.v1.completed", func(event OrderEvent) error {
+		called = true
+		return nil
+	})
+
+	err := rb.Dispatch("orders.v1.completed", []byte(`{"OrderID":123}`))
+	if !errors.Is(err, rebound.ErrDecode) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrDecode)
+	}
+
+	if called {
+		t.Error("expect the handler not to be called")
+	}
+}
+
+func TestReactToProjection(t *testing.T) {
+	type OrderPlaced struct {
+		OrderID  string
+		Customer string
+		Items    []string
+		Total    int
+		Address  string
+	}
+
+	type OrderSummary struct {
+		OrderID string
+		Total   int
+	}
+
+	rb := &rebound.Rebound{}
+
+	var got OrderSummary
+	rebound.ReactToProjection(rb, "order.placed",
+		func(event OrderPlaced) OrderSummary {
+			return OrderSummary{OrderID: event.OrderID, Total: event.Total}
+		},
+		func(summary OrderSummary) error {
+			got = summary
+			return nil
+		},
+	)
+
+	data := []byte(`{"OrderID":"123","Customer":"alice","Items":["book","pen"],"Total":42,"Address":"somewhere"}`)
+	if err := rb.Dispatch("order.placed", data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (OrderSummary{OrderID: "123", Total: 42}); got != want {
+		t.Errorf("got projection %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package rebound
+
+import (
+	"sort"
+	"sync"
+)
+
+// maxUnhandledEvents is the maximum number of distinct unhandled event names
+// tracked.
+const maxUnhandledEvents = 1024
+
+// unhandledEvents is a bounded set of unhandled event names.
+type unhandledEvents struct {
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+func (u *unhandledEvents) add(eventName string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.names) >= maxUnhandledEvents {
+		return
+	}
+
+	if u.names == nil {
+		u.names = make(map[string]struct{})
+	}
+
+	u.names[eventName] = struct{}{}
+}
+
+func (u *unhandledEvents) list() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	names := make([]string, 0, len(u.names))
+	for name := range u.names {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// UnhandledEvents returns the sorted distinct event names dispatched without
+// a handler, as tracked when WithUnhandledTracking is enabled. At most 1024
+// names are tracked.
+func (r *Rebound) UnhandledEvents() []string {
+	if r.unhandled == nil {
+		return nil
+	}
+
+	return r.unhandled.list()
+}
@@ -0,0 +1,33 @@
+package rebound_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+func TestUnhandledEvents(t *testing.T) {
+	rb := rebound.New(rebound.WithUnhandledTracking())
+
+	rb.ReactTo("order.completed", func(event OrderEvent) error { return nil })
+
+	for _, eventName := range []string{"order.shipped", "order.completed", "order.canceled", "order.shipped"} {
+		rb.Dispatch(eventName, []byte(`{}`))
+	}
+
+	want := []string{"order.canceled", "order.shipped"}
+	if got := rb.UnhandledEvents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnhandledEvents_disabled(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	rb.Dispatch("order.shipped", []byte(`{}`))
+
+	if got := rb.UnhandledEvents(); len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
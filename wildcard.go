@@ -0,0 +1,199 @@
+package rebound
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultSeparator is the default separator of the event name segments.
+const DefaultSeparator = "."
+
+// patternHandler is a handler registered for an event name pattern.
+type patternHandler struct {
+	pattern   string
+	segments  []string
+	h         *handler
+	remaining bool
+	re        *regexp.Regexp
+}
+
+// ReactToPattern registers an event handler for the event names matching
+// the given pattern. It panics if the pattern already has a handler.
+//
+// The pattern is made of segments joined by the separator (see WithSeparator),
+// where the "*" segment matches exactly one segment and the ">" segment, only
+// allowed as the last one, matches one or more trailing segments. For example,
+// "order.*" matches "order.completed" and "order.>" also matches
+// "order.item.added".
+//
+// An exact handler takes precedence over the patterns. When multiple
+// patterns match, the first registered one is used, unless ranking the
+// patterns by specificity (see WithPatternSpecificity).
+func (r *Rebound) ReactToPattern(pattern string, fn EventHandler) {
+	r.reactToPattern(pattern, fn, false)
+}
+
+// ReactToRemaining registers an event handler for the event names matching
+// the given pattern, like ReactToPattern, but only used for the event names
+// matched neither by an exact handler nor by a pattern registered using
+// ReactToPattern. This makes it a catch-all, such as "audit.>" for the audit
+// events not handled elsewhere.
+// It panics if the pattern already has a remaining handler.
+func (r *Rebound) ReactToRemaining(pattern string, fn EventHandler) {
+	r.reactToPattern(pattern, fn, true)
+}
+
+func (r *Rebound) reactToPattern(pattern string, fn EventHandler, remaining bool) {
+	if pattern == "" {
+		panic("rebound: pattern is empty")
+	}
+
+	segments := strings.Split(pattern, r.separator())
+	for i, seg := range segments {
+		if seg == "" {
+			panic(fmt.Sprintf("rebound: pattern %q has an empty segment", pattern))
+		}
+
+		if seg == ">" && i != len(segments)-1 {
+			panic(fmt.Sprintf("rebound: pattern %q has \">\" before the last segment", pattern))
+		}
+	}
+
+	err := r.validateHandler(fn)
+	if err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ph := range r.reg.patterns {
+		if ph.re == nil && ph.pattern == pattern && ph.remaining == remaining {
+			panic(fmt.Sprintf("rebound: pattern %q already has a handler", pattern))
+		}
+	}
+
+	h := newHandler(fn, 0)
+	r.checkEventType(pattern, h.eventType)
+
+	r.reg.patterns = append(r.reg.patterns, &patternHandler{
+		pattern:   pattern,
+		segments:  segments,
+		h:         h,
+		remaining: remaining,
+	})
+}
+
+// matchPattern returns the pattern handler matching the event name: the first
+// matching pattern, else the first matching regular expression, else the first
+// matching remaining pattern. When ranking by specificity, the most specific
+// matching pattern or remaining pattern is used instead of the first. It
+// requires r.mu to be held.
+func (r *Rebound) matchPattern(eventName string) *patternHandler {
+	if len(r.reg.patterns) == 0 {
+		return nil
+	}
+
+	segments := strings.Split(eventName, r.separator())
+
+	var match, re, remaining *patternHandler
+	for _, ph := range r.reg.patterns {
+		switch {
+		case ph.re != nil:
+			if re == nil && ph.re.MatchString(eventName) {
+				re = ph
+			}
+		case matchSegments(ph.segments, segments):
+			if !ph.remaining {
+				if !r.specificity {
+					return ph
+				}
+
+				match = moreSpecific(match, ph)
+				continue
+			}
+
+			if remaining == nil {
+				remaining = ph
+			} else if r.specificity {
+				remaining = moreSpecific(remaining, ph)
+			}
+		}
+	}
+
+	if match != nil {
+		return match
+	}
+
+	if re != nil {
+		return re
+	}
+
+	return remaining
+}
+
+// moreSpecific returns the more specific of the patterns, cur being
+// registered first and possibly nil. A pattern with more literal segments is
+// more specific; with as many, a pattern without ">" is more specific than a
+// pattern with ">". Otherwise, the first registered pattern is kept.
+func moreSpecific(cur, ph *patternHandler) *patternHandler {
+	if cur == nil {
+		return ph
+	}
+
+	curLiterals, curTail := patternSpecificity(cur.segments)
+	literals, tail := patternSpecificity(ph.segments)
+	switch {
+	case literals != curLiterals:
+		if literals > curLiterals {
+			return ph
+		}
+	case curTail && !tail:
+		return ph
+	}
+
+	return cur
+}
+
+// patternSpecificity returns the number of literal segments of the pattern,
+// and whether it ends with ">".
+func patternSpecificity(segments []string) (literals int, tail bool) {
+	for _, seg := range segments {
+		switch seg {
+		case "*":
+		case ">":
+			tail = true
+		default:
+			literals++
+		}
+	}
+
+	return literals, tail
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for i, seg := range pattern {
+		if seg == ">" {
+			return len(segments) > i
+		}
+
+		if i >= len(segments) {
+			return false
+		}
+
+		if seg != "*" && seg != segments[i] {
+			return false
+		}
+	}
+
+	return len(pattern) == len(segments)
+}
+
+func (r *Rebound) separator() string {
+	if r.sep == "" {
+		return DefaultSeparator
+	}
+
+	return r.sep
+}
@@ -0,0 +1,256 @@
+package rebound_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/uudashr/rebound"
+)
+
+type OrderEvent struct {
+	OrderID string
+}
+
+func TestReactToPattern(t *testing.T) {
+	testCases := map[string]struct {
+		sep       string
+		pattern   string
+		eventName string
+		match     bool
+	}{
+		"single wildcard":               {pattern: "order.*", eventName: "order.completed", match: true},
+		"single wildcard in the middle": {pattern: "order.*.added", eventName: "order.item.added", match: true},
+		"single wildcard too deep":      {pattern: "order.*", eventName: "order.item.added"},
+		"single wildcard too shallow":   {pattern: "order.*", eventName: "order"},
+		"trailing wildcard":             {pattern: "order.>", eventName: "order.item.added", match: true},
+		"trailing wildcard one segment": {pattern: "order.>", eventName: "order.completed", match: true},
+		"trailing wildcard no segment":  {pattern: "order.>", eventName: "order"},
+		"literal mismatch":              {pattern: "order.*", eventName: "payment.completed"},
+		"slash single wildcard":         {sep: "/", pattern: "order/*", eventName: "order/completed", match: true},
+		"slash trailing wildcard":       {sep: "/", pattern: "order/>", eventName: "order/item/added", match: true},
+		"slash ignores dots":            {sep: "/", pattern: "order/*", eventName: "order/item.added", match: true},
+		"slash too deep":                {sep: "/", pattern: "order/*", eventName: "order/item/added"},
+		"slash no dot splitting":        {sep: "/", pattern: "order.*", eventName: "order.completed"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var opts []rebound.Option
+			if tc.sep != "" {
+				opts = append(opts, rebound.WithSeparator(tc.sep))
+			}
+
+			rb := rebound.New(opts...)
+
+			var gotOrderID string
+			rb.ReactToPattern(tc.pattern, func(event OrderEvent) error {
+				gotOrderID = event.OrderID
+				return nil
+			})
+
+			err := rb.Dispatch(tc.eventName, []byte(`{"OrderID":"123"}`))
+
+			var noHandlerErr rebound.NoHandlerError
+			if got, want := !errors.As(err, &noHandlerErr), tc.match; got != want {
+				t.Fatalf("got match %t, want %t (error: %v)", got, want, err)
+			}
+
+			if tc.match {
+				if got, want := gotOrderID, "123"; got != want {
+					t.Errorf("got %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReactToPattern_precedence(t *testing.T) {
+	rb := rebound.New(rebound.WithSeparator("/"))
+
+	var got string
+	rb.ReactToPattern("order/*", func(event OrderEvent) error {
+		got = "first pattern"
+		return nil
+	})
+
+	rb.ReactToPattern("order/>", func(event OrderEvent) error {
+		got = "second pattern"
+		return nil
+	})
+
+	rb.ReactTo("order/completed", func(event OrderEvent) error {
+		got = "exact"
+		return nil
+	})
+
+	testCases := map[string]string{
+		"order/completed":  "exact",
+		"order/canceled":   "first pattern",
+		"order/item/added": "second pattern",
+	}
+
+	for eventName, want := range testCases {
+		if err := rb.Dispatch(eventName, []byte(`{}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("%s: got %q, want %q", eventName, got, want)
+		}
+	}
+}
+
+func TestReactToPattern_invalid(t *testing.T) {
+	patterns := []string{"", "order.>.added", "order..completed"}
+
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			rb := &rebound.Rebound{}
+
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+
+			rb.ReactToPattern(pattern, func(event OrderEvent) error { return nil })
+		})
+	}
+}
+
+func TestReactToRemaining(t *testing.T) {
+	rb := &rebound.Rebound{}
+
+	var got []string
+	rb.ReactToRemaining("audit.>", func(event OrderEvent) error {
+		got = append(got, "remaining")
+		return nil
+	})
+
+	rb.ReactTo("audit.login", func(event OrderEvent) error {
+		got = append(got, "exact")
+		return nil
+	})
+
+	rb.ReactToPattern("audit.payment.*", func(event OrderEvent) error {
+		got = append(got, "pattern")
+		return nil
+	})
+
+	testCases := map[string]struct {
+		eventName string
+		want      string
+	}{
+		"exact handler":   {eventName: "audit.login", want: "exact"},
+		"pattern handler": {eventName: "audit.payment.refunded", want: "pattern"},
+		"remaining":       {eventName: "audit.logout", want: "remaining"},
+		"remaining deep":  {eventName: "audit.order.item.added", want: "remaining"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got = nil
+			if err := rb.Dispatch(tc.eventName, []byte(`{}`)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(got) != 1 || got[0] != tc.want {
+				t.Errorf("got %v, want [%s]", got, tc.want)
+			}
+		})
+	}
+
+	if err := rb.Dispatch("order.completed", []byte(`{}`)); !errors.Is(err, rebound.ErrNoHandler) {
+		t.Errorf("got error %v, want %v", err, rebound.ErrNoHandler)
+	}
+
+	if got, want := rb.Explain("audit.logout").Kind, rebound.MatchRemaining; got != want {
+		t.Errorf("got match kind %v, want %v", got, want)
+	}
+}
+
+func TestWithPatternSpecificity(t *testing.T) {
+	patterns := []string{"order.>", "*.item.added", "order.item.>", "order.*.added", "order.*.*", "*.*.*"}
+
+	testCases := map[string]struct {
+		specificity bool
+		eventName   string
+		want        string
+	}{
+		"first registered": {
+			eventName: "order.item.added",
+			want:      "order.>",
+		},
+		"literals tie": {
+			specificity: true,
+			eventName:   "order.item.added",
+			want:        "*.item.added",
+		},
+		"star beats tail": {
+			specificity: true,
+			eventName:   "order.box.removed",
+			want:        "order.*.*",
+		},
+		"literals beat tail": {
+			specificity: true,
+			eventName:   "order.item.removed",
+			want:        "order.item.>",
+		},
+		"more literals": {
+			specificity: true,
+			eventName:   "order.item.removed.late",
+			want:        "order.item.>",
+		},
+		"single match": {
+			specificity: true,
+			eventName:   "user.item.removed",
+			want:        "*.*.*",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := rebound.New(rebound.WithPatternSpecificity(tc.specificity))
+
+			var got string
+			for _, pattern := range patterns {
+				pattern := pattern
+				rb.ReactToPattern(pattern, func(event OrderEvent) error {
+					got = pattern
+					return nil
+				})
+			}
+
+			if err := rb.Dispatch(tc.eventName, []byte(`{}`)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got pattern %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithPatternSpecificity_remaining(t *testing.T) {
+	rb := rebound.New(rebound.WithPatternSpecificity(true))
+
+	var got string
+	rb.ReactToRemaining("audit.>", func(event OrderEvent) error {
+		got = "audit.>"
+		return nil
+	})
+
+	rb.ReactToRemaining("audit.order.*", func(event OrderEvent) error {
+		got = "audit.order.*"
+		return nil
+	})
+
+	if err := rb.Dispatch("audit.order.completed", []byte(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "audit.order.*"; got != want {
+		t.Errorf("got pattern %q, want %q", got, want)
+	}
+}